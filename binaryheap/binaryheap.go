@@ -1,12 +1,21 @@
 package binaryheap
 
 import (
+	"math/bits"
+
+	"github.com/nomad-software/goad/internal/bag"
 	"golang.org/x/exp/slices"
 )
 
 // BinaryHeap is the main heap type.
+// Copying a heap by assignment shares its underlying storage with the
+// original, so changes to one may corrupt the other. Use Clone to take an
+// independent copy.
 type BinaryHeap[T comparable] struct {
 	data   []T
+	seqs   []uint64
+	seq    uint64
+	stable bool
 	pred   func(a T, b T) bool
 	sorted bool
 }
@@ -22,6 +31,26 @@ func New[T comparable](pred func(a T, b T) bool) BinaryHeap[T] {
 	}
 }
 
+// NewStable is used to create a new stable heap.
+// A stable heap tags every value with a sequence number on insertion, so
+// values that are equal according to the predicate are extracted and iterated
+// in the order they were inserted. See New for a description of the predicate.
+func NewStable[T comparable](pred func(a T, b T) bool) BinaryHeap[T] {
+	b := New(pred)
+	b.seqs = make([]uint64, 0, 16)
+	b.stable = true
+	return b
+}
+
+// FromSlice is used to create a new heap from the passed values.
+// The heap is built bottom up in linear time. See New for a description of the
+// predicate.
+func FromSlice[T comparable](vals []T, pred func(a T, b T) bool) BinaryHeap[T] {
+	b := New(pred)
+	b.InsertAll(vals...)
+	return b
+}
+
 // Count returns the amount of entries in the heap.
 func (b BinaryHeap[T]) Count() int {
 	return len(b.data)
@@ -32,11 +61,44 @@ func (b BinaryHeap[T]) Empty() bool {
 	return b.Count() == 0
 }
 
+// Stable returns true if the heap is stable, false if not.
+func (b BinaryHeap[T]) Stable() bool {
+	return b.stable
+}
+
+// Above returns true if the value at index i belongs above the value at index
+// j. Ties in a stable heap are broken by sequence number.
+func (b BinaryHeap[T]) above(i int, j int) bool {
+	if b.pred(b.data[i], b.data[j]) {
+		return true
+	}
+	return b.stable && b.seqs[i] < b.seqs[j] && !b.pred(b.data[j], b.data[i])
+}
+
+// Swap exchanges the values at the passed indexes.
+func (b *BinaryHeap[T]) swap(i int, j int) {
+	b.data[i], b.data[j] = b.data[j], b.data[i]
+	if b.stable {
+		b.seqs[i], b.seqs[j] = b.seqs[j], b.seqs[i]
+	}
+}
+
+// Append adds the passed values to the end of the dataset, tagging them with
+// sequence numbers if the heap is stable.
+func (b *BinaryHeap[T]) append(vals ...T) {
+	b.data = append(b.data, vals...)
+	if b.stable {
+		for range vals {
+			b.seqs = append(b.seqs, b.seq)
+			b.seq++
+		}
+	}
+	b.sorted = false
+}
+
 // SiftUp sifts the value at the passed index up through the heap until it finds
 // its correct position.
 func (b *BinaryHeap[T]) siftUp(childIndex int) {
-	var parent T
-	var child T
 	var parentIndex int
 
 	if childIndex > 0 {
@@ -50,12 +112,8 @@ func (b *BinaryHeap[T]) siftUp(childIndex int) {
 			parentIndex = 0
 		}
 
-		parent = b.data[parentIndex]
-		child = b.data[childIndex]
-
-		if b.pred(child, parent) {
-			b.data[parentIndex] = child
-			b.data[childIndex] = parent
+		if b.above(childIndex, parentIndex) {
+			b.swap(parentIndex, childIndex)
 
 			if parentIndex > 0 {
 				b.siftUp(parentIndex)
@@ -67,9 +125,6 @@ func (b *BinaryHeap[T]) siftUp(childIndex int) {
 // SiftDown sifts the value at the passed index down through the heap until it finds
 // its correct position.
 func (b *BinaryHeap[T]) siftDown(parentIndex int) {
-	var parent T
-	var child1 T
-	var child2 T
 	var child1Index int
 	var child2Index int
 
@@ -80,31 +135,21 @@ func (b *BinaryHeap[T]) siftDown(parentIndex int) {
 		return
 
 	} else if b.Count() == child2Index { // The parent has one child.
-		parent = b.data[parentIndex]
-		child1 = b.data[child1Index]
-
-		if b.pred(child1, parent) {
-			b.data[parentIndex] = child1
-			b.data[child1Index] = parent
+		if b.above(child1Index, parentIndex) {
+			b.swap(parentIndex, child1Index)
 			b.siftDown(child1Index)
 		}
 
 	} else { // The parent has two children.
-		parent = b.data[parentIndex]
-		child1 = b.data[child1Index]
-		child2 = b.data[child2Index]
-
 		// Compare the parent against the greater child.
-		if b.pred(child1, child2) {
-			if b.pred(child1, parent) {
-				b.data[parentIndex] = child1
-				b.data[child1Index] = parent
+		if b.above(child1Index, child2Index) {
+			if b.above(child1Index, parentIndex) {
+				b.swap(parentIndex, child1Index)
 				b.siftDown(child1Index)
 			}
 		} else {
-			if b.pred(child2, parent) {
-				b.data[parentIndex] = child2
-				b.data[child2Index] = parent
+			if b.above(child2Index, parentIndex) {
+				b.swap(parentIndex, child2Index)
 				b.siftDown(child2Index)
 			}
 		}
@@ -113,9 +158,30 @@ func (b *BinaryHeap[T]) siftDown(parentIndex int) {
 
 // Insert inserts a new value into the heap.
 func (b *BinaryHeap[T]) Insert(val T) {
-	b.data = append(b.data, val)
+	b.append(val)
 	b.siftUp(b.Count() - 1)
-	b.sorted = false
+}
+
+// InsertAll inserts the passed values into the heap.
+// When enough values are passed the heap is rebuilt bottom up in linear time,
+// instead of sifting up each value in turn.
+func (b *BinaryHeap[T]) InsertAll(vals ...T) {
+	if len(vals) == 0 {
+		return
+	}
+
+	b.append(vals...)
+
+	if len(vals) < b.Count()/bits.Len(uint(b.Count())) {
+		for i := b.Count() - len(vals); i < b.Count(); i++ {
+			b.siftUp(i)
+		}
+		return
+	}
+
+	for i := b.Count()/2 - 1; i >= 0; i-- {
+		b.siftDown(i)
+	}
 }
 
 // Peek returns the first value at the top of the heap.
@@ -129,8 +195,13 @@ func (b *BinaryHeap[T]) Extract() T {
 		panic("binary heap empty, extracting failed")
 	}
 	val := b.data[0]
-	b.data[0] = b.data[b.Count()-1]
-	b.data = b.data[0 : b.Count()-1]
+	last := b.Count() - 1
+	b.data[0] = b.data[last]
+	b.data = b.data[0:last]
+	if b.stable {
+		b.seqs[0] = b.seqs[last]
+		b.seqs = b.seqs[0:last]
+	}
 	b.siftDown(0)
 	b.sorted = false
 	return val
@@ -146,9 +217,21 @@ func (b BinaryHeap[T]) Contains(val T) bool {
 	return false
 }
 
+// Drain removes every value from the heap, returning them in the order they
+// would have been extracted.
+func (b *BinaryHeap[T]) Drain() []T {
+	b.sort()
+	vals := b.data
+	b.Clear()
+	return vals
+}
+
 // Clear empties the entire heap.
 func (b *BinaryHeap[T]) Clear() {
 	b.data = b.data[:0:0]
+	if b.stable {
+		b.seqs = b.seqs[:0:0]
+	}
 }
 
 // Sort the heap ready for iterating.
@@ -158,11 +241,36 @@ func (b *BinaryHeap[T]) Clear() {
 // when sifting.
 func (b *BinaryHeap[T]) sort() {
 	if !b.sorted {
-		slices.SortFunc(b.data, b.pred)
+		if b.stable {
+			data, seqs := b.sortedCopy()
+			copy(b.data, data)
+			copy(b.seqs, seqs)
+		} else {
+			slices.SortFunc(b.data, b.pred)
+		}
 		b.sorted = true
 	}
 }
 
+// SortedCopy returns a copy of the values and sequence numbers of a stable heap
+// in the order they would be extracted.
+func (b BinaryHeap[T]) sortedCopy() ([]T, []uint64) {
+	order := make([]int, b.Count())
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, b.above)
+
+	data := make([]T, len(order))
+	seqs := make([]uint64, len(order))
+	for i, j := range order {
+		data[i] = b.data[j]
+		seqs[i] = b.seqs[j]
+	}
+
+	return data, seqs
+}
+
 // ForEach iterates over the dataset within the heap, calling the passed
 // function for each value.
 func (b BinaryHeap[T]) ForEach(f func(val T)) {
@@ -171,3 +279,34 @@ func (b BinaryHeap[T]) ForEach(f func(val T)) {
 		f(v)
 	}
 }
+
+// ToSlice returns the values of the heap in the order they would be extracted.
+func (b BinaryHeap[T]) ToSlice() []T {
+	if b.stable {
+		vals, _ := b.sortedCopy()
+		return vals
+	}
+
+	vals := slices.Clone(b.data)
+	slices.SortFunc(vals, b.pred)
+	return vals
+}
+
+// Clone returns a copy of the heap that shares no storage with the original.
+// The copy uses the same predicate and stability as the original.
+func (b BinaryHeap[T]) Clone() BinaryHeap[T] {
+	return BinaryHeap[T]{
+		data:   slices.Clone(b.data),
+		seqs:   slices.Clone(b.seqs),
+		seq:    b.seq,
+		stable: b.stable,
+		pred:   b.pred,
+		sorted: b.sorted,
+	}
+}
+
+// Equal returns true if both heaps contain the same values the same amount of
+// times, false if not. The order of the values within each heap is ignored.
+func (b BinaryHeap[T]) Equal(other BinaryHeap[T]) bool {
+	return bag.Equal(b.data, other.data)
+}
@@ -1,9 +1,12 @@
 package binaryheap
 
 import (
+	"math/rand"
 	"testing"
+	"testing/quick"
 
 	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
 )
 
 func TestNew(t *testing.T) {
@@ -176,6 +179,240 @@ func TestForEach(t *testing.T) {
 	})
 }
 
+func TestInsertAll(t *testing.T) {
+	t.Parallel()
+
+	b := New(func(a, b int) bool { return a < b })
+	b.InsertAll()
+	assert.True(t, b.Empty())
+
+	b.InsertAll(6, 5, 2, 9, 4, 8, 7, 1, 3, 10)
+	assert.Eq(t, b.Count(), 10)
+
+	b.Insert(0)
+	b.InsertAll(11)
+	b.InsertAll(-1, 12)
+	assert.Eq(t, b.Count(), 14)
+
+	for i := -1; i <= 12; i++ {
+		assert.Eq(t, b.Extract(), i)
+	}
+
+	limit := 1_000
+	for i := limit; i > 0; i-- {
+		b.Insert(i)
+	}
+
+	vals := make([]int, 0, limit)
+	for i := limit; i > 0; i-- {
+		vals = append(vals, i+limit)
+	}
+	b.InsertAll(vals...)
+
+	for i := 1; i <= 2*limit; i++ {
+		assert.Eq(t, b.Extract(), i)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	t.Parallel()
+
+	b := New(func(a, b int) bool { return a > b })
+	b.InsertAll(3, 1, 4, 1, 5, 9, 2, 6)
+
+	vals := b.Drain()
+	assert.True(t, slices.Equal(vals, []int{9, 6, 5, 4, 3, 2, 1, 1}))
+	assert.True(t, b.Empty())
+
+	b.Insert(7)
+	assert.True(t, slices.Equal(vals, []int{9, 6, 5, 4, 3, 2, 1, 1}))
+	assert.Eq(t, b.Extract(), 7)
+	assert.Eq(t, len(b.Drain()), 0)
+}
+
+func TestSlices(t *testing.T) {
+	t.Parallel()
+
+	vals := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	b := FromSlice(vals, func(a, b int) bool { return a < b })
+
+	assert.Eq(t, b.Count(), 8)
+	assert.Eq(t, b.Peek(), 1)
+	assert.True(t, slices.Equal(vals, []int{3, 1, 4, 1, 5, 9, 2, 6}))
+	assert.True(t, slices.Equal(b.ToSlice(), []int{1, 1, 2, 3, 4, 5, 6, 9}))
+	assert.Eq(t, b.Count(), 8)
+
+	for _, v := range []int{1, 1, 2, 3, 4, 5, 6, 9} {
+		assert.Eq(t, b.Extract(), v)
+	}
+
+	assert.True(t, FromSlice(nil, func(a, b int) bool { return a < b }).Empty())
+}
+
+// Job is used to test the ordering of values with equal priorities.
+type job struct {
+	priority int
+	id       int
+}
+
+func byPriority(a, b job) bool { return a.priority > b.priority }
+
+func TestStable(t *testing.T) {
+	t.Parallel()
+
+	b := NewStable(byPriority)
+	assert.True(t, b.Stable())
+	assert.False(t, New(byPriority).Stable())
+
+	for i := 0; i < 10; i++ {
+		b.Insert(job{priority: i % 2, id: i})
+	}
+
+	for _, id := range []int{1, 3, 5, 7, 9, 0, 2, 4, 6, 8} {
+		assert.Eq(t, b.Extract().id, id)
+	}
+}
+
+func TestStableForEach(t *testing.T) {
+	t.Parallel()
+
+	b := NewStable(byPriority)
+	b.InsertAll(job{1, 0}, job{0, 1}, job{1, 2}, job{0, 3})
+	b.Insert(job{1, 4})
+
+	expected := []int{0, 2, 4, 1, 3}
+
+	var i int
+	b.ForEach(func(val job) {
+		assert.Eq(t, val.id, expected[i])
+		i++
+	})
+
+	vals := b.ToSlice()
+	for i, id := range expected {
+		assert.Eq(t, vals[i].id, id)
+	}
+
+	c := b.Clone()
+	for _, id := range expected {
+		assert.Eq(t, b.Extract().id, id)
+	}
+
+	vals = c.Drain()
+	for i, id := range expected {
+		assert.Eq(t, vals[i].id, id)
+	}
+}
+
+// Extracting every value from a stable heap must deliver values of equal
+// priority in insertion order, however the values are inserted.
+func TestStableProperty(t *testing.T) {
+	t.Parallel()
+
+	property := func(priorities []uint8, bulk bool) bool {
+		jobs := make([]job, len(priorities))
+		for i, p := range priorities {
+			jobs[i] = job{priority: int(p % 4), id: i}
+		}
+
+		b := NewStable(byPriority)
+		if bulk {
+			b.InsertAll(jobs...)
+		} else {
+			for _, j := range jobs {
+				b.Insert(j)
+			}
+		}
+
+		expected := slices.Clone(jobs)
+		slices.SortStableFunc(expected, byPriority)
+
+		if !slices.Equal(b.ToSlice(), expected) {
+			return false
+		}
+
+		for _, j := range expected {
+			if b.Extract() != j {
+				return false
+			}
+		}
+
+		return b.Empty()
+	}
+
+	assert.Eq(t, quick.Check(property, nil), nil)
+}
+
+// Interleaving insertions and extractions must still deliver values of equal
+// priority in insertion order.
+func TestStableRandomised(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+	b := NewStable(byPriority)
+	last := make(map[int]int)
+
+	for i := 0; i < 20_000; i++ {
+		if r.Intn(3) > 0 || b.Empty() {
+			b.Insert(job{priority: r.Intn(5), id: i})
+			continue
+		}
+
+		j := b.Extract()
+		if id, ok := last[j.priority]; ok {
+			assert.True(t, j.id > id)
+		}
+		last[j.priority] = j.id
+	}
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	b := New(func(a, b int) bool { return a < b })
+	b.Insert(3)
+	b.Insert(1)
+	b.Insert(2)
+
+	c := b.Clone()
+	assert.True(t, c.Equal(b))
+
+	c.Extract()
+	c.Insert(4)
+	b.Insert(0)
+
+	assert.False(t, c.Equal(b))
+	assert.Eq(t, b.Count(), 4)
+	assert.Eq(t, c.Count(), 3)
+
+	for _, v := range []int{0, 1, 2, 3} {
+		assert.Eq(t, b.Extract(), v)
+	}
+	for _, v := range []int{2, 3, 4} {
+		assert.Eq(t, c.Extract(), v)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := New(func(a, b int) bool { return a < b })
+	b := New(func(a, b int) bool { return a < b })
+	assert.True(t, a.Equal(b))
+
+	for _, v := range []int{5, 1, 4, 1, 3} {
+		a.Insert(v)
+	}
+	for _, v := range []int{1, 3, 1, 4, 5} {
+		b.Insert(v)
+	}
+	assert.True(t, a.Equal(b))
+
+	b.Extract()
+	b.Insert(2)
+	assert.False(t, a.Equal(b))
+}
+
 func BenchmarkBinaryHeapInsert(b *testing.B) {
 	h := New(func(a, b int) bool { return a < b })
 
@@ -201,3 +438,29 @@ func BenchmarkBinaryHeapForEach(b *testing.B) {
 		h.ForEach(func(val int) {})
 	}
 }
+
+func BenchmarkBinaryHeapStableInsert(b *testing.B) {
+	h := NewStable(func(a, b int) bool { return a < b })
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		h.Insert(x % 100)
+	}
+}
+
+func BenchmarkBinaryHeapInsertAll(b *testing.B) {
+	vals := make([]int, 1_000)
+	for i := range vals {
+		vals[i] = len(vals) - i
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		h := New(func(a, b int) bool { return a < b })
+		h.InsertAll(vals...)
+	}
+}
@@ -0,0 +1,86 @@
+package binaryheap
+
+import (
+	"iter"
+	"slices"
+)
+
+// Source is a value taken from one of the sequences being merged, along with
+// the index of that sequence.
+type source[T comparable] struct {
+	val   T
+	index int
+}
+
+// Merge returns a sequence of the values of every passed sequence in sorted
+// order. Each sequence must already be sorted according to the passed
+// predicate, which returns true if the first parameter belongs before the
+// second. The merge is stable, so equal values are returned in the order of
+// the sequences they came from and then in the order each sequence returned
+// them. The merge holds at most one value per sequence at any time and doesn't
+// pull any values until the returned sequence is ranged over. Stopping early
+// stops every passed sequence.
+func Merge[T comparable](pred func(a T, b T) bool, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		b := BinaryHeap[source[T]]{
+			data: make([]source[T], 0, len(seqs)),
+			pred: func(x source[T], y source[T]) bool {
+				if pred(x.val, y.val) {
+					return true
+				}
+				return x.index < y.index && !pred(y.val, x.val)
+			},
+		}
+
+		nexts := make([]func() (T, bool), len(seqs))
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+
+			nexts[i] = next
+			if v, ok := next(); ok {
+				b.data = append(b.data, source[T]{val: v, index: i})
+			}
+		}
+
+		for i := b.Count()/2 - 1; i >= 0; i-- {
+			b.siftDown(i)
+		}
+
+		for !b.Empty() {
+			top := b.data[0]
+			if !yield(top.val) {
+				return
+			}
+
+			// Refill from the same sequence in place of the top, to avoid
+			// sifting twice.
+			if v, ok := nexts[top.index](); ok {
+				b.data[0] = source[T]{val: v, index: top.index}
+				b.siftDown(0)
+			} else {
+				b.Extract()
+			}
+		}
+	}
+}
+
+// MergeSlices returns a new slice containing the values of every passed slice
+// in sorted order. See Merge for a description of the predicate and the
+// ordering of equal values.
+func MergeSlices[T comparable](pred func(a T, b T) bool, vals ...[]T) []T {
+	var total int
+	seqs := make([]iter.Seq[T], len(vals))
+
+	for i, v := range vals {
+		total += len(v)
+		seqs[i] = slices.Values(v)
+	}
+
+	result := make([]T, 0, total)
+	for v := range Merge(pred, seqs...) {
+		result = append(result, v)
+	}
+
+	return result
+}
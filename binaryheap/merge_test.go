@@ -0,0 +1,154 @@
+package binaryheap
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/nomad-software/assert"
+)
+
+func ascending(a, b int) bool { return a < b }
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	seq := Merge(ascending,
+		slices.Values([]int{1, 4, 7}),
+		slices.Values([]int{2, 5, 8}),
+		slices.Values([]int{3, 6, 9}),
+	)
+
+	var i int
+	for v := range seq {
+		i++
+		assert.Eq(t, v, i)
+	}
+	assert.Eq(t, i, 9)
+
+	// Ranging again merges the sequences afresh.
+	assert.True(t, slices.Equal(slices.Collect(seq), []int{1, 2, 3, 4, 5, 6, 7, 8, 9}))
+}
+
+func TestMergeEmpty(t *testing.T) {
+	t.Parallel()
+
+	for range Merge[int](ascending) {
+		t.Fatal("value returned from an empty merge")
+	}
+
+	assert.Eq(t, len(MergeSlices[int](ascending)), 0)
+	assert.Eq(t, len(MergeSlices(ascending, nil, []int{})), 0)
+
+	vals := MergeSlices(ascending, nil, []int{2, 3}, []int{}, []int{1})
+	assert.True(t, slices.Equal(vals, []int{1, 2, 3}))
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	t.Parallel()
+
+	vals := MergeSlices(ascending, []int{1, 1, 2}, []int{1, 2, 2}, []int{2})
+	assert.True(t, slices.Equal(vals, []int{1, 1, 1, 2, 2, 2, 2}))
+}
+
+func TestMergeStable(t *testing.T) {
+	t.Parallel()
+
+	type log struct {
+		time   int
+		source string
+		line   int
+	}
+
+	byTime := func(a, b log) bool { return a.time < b.time }
+
+	a := []log{{1, "a", 0}, {2, "a", 1}, {2, "a", 2}, {5, "a", 3}}
+	b := []log{{2, "b", 0}, {3, "b", 1}, {5, "b", 2}}
+	c := []log{{1, "c", 0}, {2, "c", 1}}
+
+	expected := []log{
+		{1, "a", 0}, {1, "c", 0},
+		{2, "a", 1}, {2, "a", 2}, {2, "b", 0}, {2, "c", 1},
+		{3, "b", 1},
+		{5, "a", 3}, {5, "b", 2},
+	}
+
+	assert.True(t, slices.Equal(MergeSlices(byTime, a, b, c), expected))
+}
+
+func TestMergeLazy(t *testing.T) {
+	t.Parallel()
+
+	var pulls, stops int
+	counting := func(vals []int) iter.Seq[int] {
+		return func(yield func(int) bool) {
+			defer func() { stops++ }()
+			for _, v := range vals {
+				pulls++
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+
+	seq := Merge(ascending, counting([]int{1, 3}), counting([]int{2, 4}))
+	assert.Eq(t, pulls, 0)
+
+	// Stopping early stops every sequence.
+	for v := range seq {
+		assert.Eq(t, v, 1)
+		break
+	}
+	assert.Eq(t, pulls, 2)
+	assert.Eq(t, stops, 2)
+}
+
+func TestMergeRandomised(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+	vals := make([][]int, 50)
+	all := make([]int, 0)
+
+	for i := range vals {
+		vals[i] = make([]int, r.Intn(200))
+		for j := range vals[i] {
+			vals[i][j] = r.Intn(1_000)
+		}
+		slices.Sort(vals[i])
+		all = append(all, vals[i]...)
+	}
+	slices.Sort(all)
+
+	assert.True(t, slices.Equal(MergeSlices(ascending, vals...), all))
+
+	descending := func(a, b int) bool { return a > b }
+	for i := range vals {
+		slices.Reverse(vals[i])
+	}
+	slices.Reverse(all)
+
+	assert.True(t, slices.Equal(MergeSlices(descending, vals...), all))
+}
+
+func BenchmarkMergeSlices(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	vals := make([][]int, 16)
+
+	for i := range vals {
+		vals[i] = make([]int, 100_000)
+		for j := range vals[i] {
+			vals[i][j] = r.Int()
+		}
+		slices.Sort(vals[i])
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		MergeSlices(ascending, vals...)
+	}
+}
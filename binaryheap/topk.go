@@ -0,0 +1,124 @@
+package binaryheap
+
+import (
+	"golang.org/x/exp/slices"
+)
+
+// TopK keeps the highest ranked values from a stream of values, up to a fixed
+// capacity. Internally it is a heap with the lowest ranked kept value on top,
+// so every insertion is either rejected or replaces that value in logarithmic
+// time. A copy made by assignment shares the slice of kept values, so an
+// insertion through one may replace values kept by the other. Use Clone to
+// take a collector of its own.
+type TopK[T comparable] struct {
+	heap BinaryHeap[T]
+	k    int
+	pred func(a T, b T) bool
+}
+
+// NewTopK is used to create a new collector that keeps up to k values.
+// The passed function is a predicate that returns true if the first parameter
+// is greater than the second. This predicate defines which values rank
+// highest and are kept.
+func NewTopK[T comparable](k int, pred func(a T, b T) bool) TopK[T] {
+	if k < 1 {
+		panic("top-k capacity must be greater than zero")
+	}
+
+	return TopK[T]{
+		heap: BinaryHeap[T]{
+			data: make([]T, 0, k),
+			pred: func(a T, b T) bool { return pred(b, a) },
+		},
+		k:    k,
+		pred: pred,
+	}
+}
+
+// Capacity returns the maximum amount of values kept.
+func (t TopK[T]) Capacity() int {
+	return t.k
+}
+
+// Count returns the amount of values currently kept.
+func (t TopK[T]) Count() int {
+	return t.heap.Count()
+}
+
+// Empty returns true if no values are kept, false if not.
+func (t TopK[T]) Empty() bool {
+	return t.Count() == 0
+}
+
+// Full returns true if the collector has reached its capacity, false if not.
+func (t TopK[T]) Full() bool {
+	return t.Count() == t.k
+}
+
+// Insert offers a value to the collector, returning true if it was kept or
+// false if it was rejected. When full, a value is only kept if it ranks higher
+// than the lowest ranked kept value, which it then replaces.
+func (t *TopK[T]) Insert(val T) bool {
+	if !t.Full() {
+		t.heap.Insert(val)
+		return true
+	}
+
+	if !t.pred(val, t.heap.data[0]) {
+		return false
+	}
+
+	t.heap.data[0] = val
+	t.heap.siftDown(0)
+	t.heap.sorted = false
+
+	return true
+}
+
+// Threshold returns the lowest ranked value currently kept. Once the collector
+// is full, values must rank higher than this to be kept.
+func (t TopK[T]) Threshold() T {
+	if t.Empty() {
+		panic("top-k empty, getting threshold failed")
+	}
+	return t.heap.data[0]
+}
+
+// Contains returns true if the value is currently kept, false if not.
+func (t TopK[T]) Contains(val T) bool {
+	return t.heap.Contains(val)
+}
+
+// Clear discards every kept value.
+func (t *TopK[T]) Clear() {
+	t.heap.data = t.heap.data[:0]
+}
+
+// ToSlice returns the kept values from highest to lowest ranked.
+func (t TopK[T]) ToSlice() []T {
+	vals := slices.Clone(t.heap.data)
+	slices.SortFunc(vals, t.pred)
+	return vals
+}
+
+// ForEach iterates over the kept values from highest to lowest ranked, calling
+// the passed function for each value.
+func (t TopK[T]) ForEach(f func(val T)) {
+	for _, v := range t.ToSlice() {
+		f(v)
+	}
+}
+
+// Clone returns a copy of the collector that shares no storage with the
+// original. The copy uses the same capacity and predicate as the original.
+func (t TopK[T]) Clone() TopK[T] {
+	c := t
+	c.heap = t.heap.Clone()
+	return c
+}
+
+// Equal returns true if both collectors keep the same values the same amount
+// of times, false if not. The capacity of each collector is ignored.
+func (t TopK[T]) Equal(other TopK[T]) bool {
+	return t.heap.Equal(other.heap)
+}
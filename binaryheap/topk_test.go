@@ -0,0 +1,188 @@
+package binaryheap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
+)
+
+func TestNewTopK(t *testing.T) {
+	t.Parallel()
+
+	k := NewTopK(3, func(a, b int) bool { return a > b })
+	assert.True(t, k.Empty())
+	assert.Eq(t, k.Capacity(), 3)
+
+	assert.True(t, k.Insert(5))
+	assert.True(t, k.Insert(1))
+	assert.True(t, k.Insert(3))
+	assert.True(t, k.Full())
+	assert.Eq(t, k.Threshold(), 1)
+
+	assert.False(t, k.Insert(0))
+	assert.False(t, k.Insert(1))
+	assert.True(t, k.Insert(4))
+	assert.True(t, k.Insert(9))
+
+	assert.Eq(t, k.Count(), 3)
+	assert.Eq(t, k.Threshold(), 4)
+	assert.True(t, k.Contains(9))
+	assert.False(t, k.Contains(3))
+	assert.True(t, slices.Equal(k.ToSlice(), []int{9, 5, 4}))
+}
+
+func TestTopKSmallest(t *testing.T) {
+	t.Parallel()
+
+	k := NewTopK(2, func(a, b string) bool { return len(a) < len(b) })
+	for _, s := range []string{"ccc", "a", "dddd", "bb", "eeeee"} {
+		k.Insert(s)
+	}
+
+	assert.True(t, slices.Equal(k.ToSlice(), []string{"a", "bb"}))
+}
+
+func TestFailedNewTopK(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	NewTopK(0, func(a, b int) bool { return a > b })
+}
+
+func TestFailedTopKThreshold(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	k := NewTopK(1, func(a, b int) bool { return a > b })
+	k.Threshold()
+}
+
+func TestTopKRandomised(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+
+	for _, size := range []int{1, 10, 100} {
+		k := NewTopK(size, func(a, b int) bool { return a > b })
+		vals := make([]int, 10_000)
+
+		for i := range vals {
+			vals[i] = r.Intn(1_000)
+			k.Insert(vals[i])
+		}
+
+		slices.SortFunc(vals, func(a, b int) bool { return a > b })
+		assert.True(t, slices.Equal(k.ToSlice(), vals[:size]))
+		assert.Eq(t, k.Threshold(), vals[size-1])
+	}
+}
+
+func TestTopKClearing(t *testing.T) {
+	t.Parallel()
+
+	k := NewTopK(2, func(a, b int) bool { return a > b })
+	k.Insert(1)
+	k.Insert(2)
+
+	k.Clear()
+	assert.True(t, k.Empty())
+
+	k.Insert(0)
+	assert.Eq(t, k.Threshold(), 0)
+}
+
+func TestTopKForEach(t *testing.T) {
+	t.Parallel()
+
+	k := NewTopK(3, func(a, b int) bool { return a > b })
+	for i := 0; i < 10; i++ {
+		k.Insert(i)
+	}
+
+	i := 9
+	k.ForEach(func(val int) {
+		assert.Eq(t, val, i)
+		i--
+	})
+	assert.Eq(t, i, 6)
+}
+
+func TestTopKClone(t *testing.T) {
+	t.Parallel()
+
+	k := NewTopK(2, func(a, b int) bool { return a > b })
+	k.Insert(1)
+	k.Insert(2)
+
+	c := k.Clone()
+	assert.True(t, c.Equal(k))
+
+	c.Insert(3)
+	assert.False(t, c.Equal(k))
+	assert.True(t, slices.Equal(k.ToSlice(), []int{2, 1}))
+	assert.True(t, slices.Equal(c.ToSlice(), []int{3, 2}))
+}
+
+func BenchmarkTopKInsert(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	k := NewTopK(100, func(a, b int) bool { return a > b })
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		k.Insert(r.Int())
+	}
+}
+
+func BenchmarkTopKStream(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	vals := make([]int, 1_000_000)
+	for i := range vals {
+		vals[i] = r.Int()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		k := NewTopK(100, func(a, b int) bool { return a > b })
+		for _, v := range vals {
+			k.Insert(v)
+		}
+		k.ToSlice()
+	}
+}
+
+func BenchmarkBinaryHeapStream(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	vals := make([]int, 1_000_000)
+	for i := range vals {
+		vals[i] = r.Int()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		h := New(func(a, b int) bool { return a > b })
+		for _, v := range vals {
+			h.Insert(v)
+		}
+		for i := 0; i < 100; i++ {
+			h.Extract()
+		}
+	}
+}
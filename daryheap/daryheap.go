@@ -0,0 +1,228 @@
+package daryheap
+
+import (
+	"github.com/nomad-software/goad/internal/bag"
+	"golang.org/x/exp/slices"
+)
+
+// DaryHeap is the main heap type.
+// Every node has up to d children, so the tree is shallower than a binary heap
+// and sifting touches fewer, more closely packed levels. A copy made by
+// assignment sifts values around the same slice as the original. Use Clone to
+// take a heap with its own storage.
+type DaryHeap[T comparable] struct {
+	data   []T
+	arity  int
+	pred   func(a T, b T) bool
+	sorted bool
+}
+
+// New is used to create a new heap where every node has up to d children.
+// The passed function is a predicate that returns true if the first parameter
+// is greater than the second. This predicate defines the sorting order between
+// the heap items and is called during insertion and extraction.
+func New[T comparable](d int, pred func(a T, b T) bool) DaryHeap[T] {
+	if d < 2 {
+		panic("heap arity must be at least two")
+	}
+
+	return DaryHeap[T]{
+		data:  make([]T, 0, 16),
+		arity: d,
+		pred:  pred,
+	}
+}
+
+// FromSlice is used to create a new heap from the passed values.
+// The heap is built bottom up in linear time. See New for a description of the
+// arity and predicate.
+func FromSlice[T comparable](vals []T, d int, pred func(a T, b T) bool) DaryHeap[T] {
+	h := New(d, pred)
+	h.data = slices.Clone(vals)
+	h.heapify()
+	return h
+}
+
+// Arity returns the maximum amount of children of each node in the heap.
+func (h DaryHeap[T]) Arity() int {
+	return h.arity
+}
+
+// Count returns the amount of entries in the heap.
+func (h DaryHeap[T]) Count() int {
+	return len(h.data)
+}
+
+// Empty returns true if the heap is empty, false if not.
+func (h DaryHeap[T]) Empty() bool {
+	return h.Count() == 0
+}
+
+// SiftUp sifts the value at the passed index up through the heap until it finds
+// its correct position.
+func (h *DaryHeap[T]) siftUp(i int) {
+	val := h.data[i]
+
+	for i > 0 {
+		parent := (i - 1) / h.arity
+		if !h.pred(val, h.data[parent]) {
+			break
+		}
+		h.data[i] = h.data[parent]
+		i = parent
+	}
+
+	h.data[i] = val
+}
+
+// SiftDown sifts the value at the passed index down through the heap until it
+// finds its correct position.
+func (h *DaryHeap[T]) siftDown(i int) {
+	val := h.data[i]
+
+	for {
+		first := h.arity*i + 1
+		if first >= h.Count() {
+			break
+		}
+
+		// Find the greatest child.
+		best := first
+		end := min(first+h.arity, h.Count())
+		for c := first + 1; c < end; c++ {
+			if h.pred(h.data[c], h.data[best]) {
+				best = c
+			}
+		}
+
+		if !h.pred(h.data[best], val) {
+			break
+		}
+
+		h.data[i] = h.data[best]
+		i = best
+	}
+
+	h.data[i] = val
+}
+
+// Heapify restores the heap property over the entire dataset.
+func (h *DaryHeap[T]) heapify() {
+	if h.Count() < 2 {
+		return
+	}
+
+	for i := (h.Count() - 2) / h.arity; i >= 0; i-- {
+		h.siftDown(i)
+	}
+}
+
+// Insert inserts a new value into the heap.
+func (h *DaryHeap[T]) Insert(val T) {
+	h.data = append(h.data, val)
+	h.siftUp(h.Count() - 1)
+	h.sorted = false
+}
+
+// InsertAll inserts the passed values into the heap, rebuilding it bottom up
+// in linear time.
+func (h *DaryHeap[T]) InsertAll(vals ...T) {
+	h.data = append(h.data, vals...)
+	h.heapify()
+	h.sorted = false
+}
+
+// Peek returns the first value at the top of the heap.
+func (h DaryHeap[T]) Peek() T {
+	if h.Empty() {
+		panic("d-ary heap empty, peeking failed")
+	}
+	return h.data[0]
+}
+
+// Extract returns and removes the first value from the heap.
+func (h *DaryHeap[T]) Extract() T {
+	if h.Empty() {
+		panic("d-ary heap empty, extracting failed")
+	}
+
+	val := h.data[0]
+	last := h.Count() - 1
+	h.data[0] = h.data[last]
+	h.data = h.data[0:last]
+
+	if last > 0 {
+		h.siftDown(0)
+	}
+
+	h.sorted = false
+	return val
+}
+
+// Contains returns true if the value exists in the heap, false if not.
+func (h DaryHeap[T]) Contains(val T) bool {
+	for _, v := range h.data {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+// Clear empties the entire heap.
+func (h *DaryHeap[T]) Clear() {
+	h.data = h.data[:0:0]
+}
+
+// Sort the heap ready for iterating.
+// A sorted slice is also a valid heap, so the heap can be sorted in place
+// without breaking it.
+func (h *DaryHeap[T]) sort() {
+	if !h.sorted {
+		slices.SortFunc(h.data, h.pred)
+		h.sorted = true
+	}
+}
+
+// Drain removes every value from the heap, returning them in the order they
+// would have been extracted.
+func (h *DaryHeap[T]) Drain() []T {
+	h.sort()
+	vals := h.data
+	h.Clear()
+	return vals
+}
+
+// ToSlice returns the values of the heap in the order they would be extracted.
+func (h DaryHeap[T]) ToSlice() []T {
+	vals := slices.Clone(h.data)
+	slices.SortFunc(vals, h.pred)
+	return vals
+}
+
+// ForEach iterates over the dataset within the heap, calling the passed
+// function for each value.
+func (h DaryHeap[T]) ForEach(f func(val T)) {
+	h.sort()
+	for _, v := range h.data {
+		f(v)
+	}
+}
+
+// Clone returns a copy of the heap that shares no storage with the original.
+// The copy uses the same arity and predicate as the original.
+func (h DaryHeap[T]) Clone() DaryHeap[T] {
+	return DaryHeap[T]{
+		data:   slices.Clone(h.data),
+		arity:  h.arity,
+		pred:   h.pred,
+		sorted: h.sorted,
+	}
+}
+
+// Equal returns true if both heaps contain the same values the same amount of
+// times, false if not. The order of the values within each heap and the arity
+// of each heap are ignored.
+func (h DaryHeap[T]) Equal(other DaryHeap[T]) bool {
+	return bag.Equal(h.data, other.data)
+}
@@ -0,0 +1,208 @@
+package daryheap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
+)
+
+func ascending(a, b int) bool { return a < b }
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	h := New(4, ascending)
+	assert.True(t, h.Empty())
+	assert.Eq(t, h.Arity(), 4)
+
+	for _, v := range []int{6, 5, 2, 9, 4, 8, 7, 1, 3, 10} {
+		h.Insert(v)
+	}
+
+	assert.False(t, h.Empty())
+	assert.Eq(t, h.Count(), 10)
+
+	for i := 1; i <= 10; i++ {
+		assert.Eq(t, h.Peek(), i)
+		assert.Eq(t, h.Extract(), i)
+	}
+
+	assert.True(t, h.Empty())
+}
+
+func TestFailedNew(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	New(1, ascending)
+}
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+
+	type Foo struct {
+		Foo int
+		Bar string
+	}
+
+	h := New(3, func(a, b Foo) bool { return a.Foo > b.Foo })
+
+	f1 := Foo{Foo: 2, Bar: "bar"}
+	f2 := Foo{Foo: 4, Bar: "qux"}
+	f3 := Foo{Foo: 3, Bar: "baz"}
+	f4 := Foo{Foo: 1, Bar: "foo"}
+
+	h.Insert(f1)
+	h.Insert(f2)
+	h.Insert(f3)
+	h.Insert(f4)
+
+	assert.True(t, h.Contains(f4))
+	assert.Eq(t, h.Extract(), f2)
+	assert.Eq(t, h.Extract(), f3)
+	assert.Eq(t, h.Extract(), f1)
+	assert.Eq(t, h.Extract(), f4)
+	assert.False(t, h.Contains(f4))
+}
+
+func TestFailedExtract(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	h := New(2, ascending)
+	h.Extract()
+}
+
+func TestArities(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+
+	for d := 2; d <= 8; d++ {
+		vals := make([]int, 1_000)
+		for i := range vals {
+			vals[i] = r.Intn(500)
+		}
+
+		sorted := slices.Clone(vals)
+		slices.Sort(sorted)
+
+		a := New(d, ascending)
+		for _, v := range vals {
+			a.Insert(v)
+		}
+
+		b := FromSlice(vals, d, ascending)
+
+		c := New(d, ascending)
+		c.InsertAll(vals[:500]...)
+		c.InsertAll(vals[500:]...)
+
+		for _, v := range sorted {
+			assert.Eq(t, a.Extract(), v)
+			assert.Eq(t, b.Extract(), v)
+			assert.Eq(t, c.Extract(), v)
+		}
+	}
+}
+
+func TestSlices(t *testing.T) {
+	t.Parallel()
+
+	h := FromSlice([]int{3, 1, 4, 1, 5}, 3, ascending)
+	assert.True(t, slices.Equal(h.ToSlice(), []int{1, 1, 3, 4, 5}))
+	assert.Eq(t, h.Count(), 5)
+
+	assert.True(t, slices.Equal(h.Drain(), []int{1, 1, 3, 4, 5}))
+	assert.True(t, h.Empty())
+
+	assert.True(t, FromSlice(nil, 3, ascending).Empty())
+	assert.True(t, FromSlice([]int{1}, 3, ascending).Count() == 1)
+}
+
+func TestClearing(t *testing.T) {
+	t.Parallel()
+
+	h := FromSlice([]int{3, 1, 2}, 4, ascending)
+	h.Clear()
+	assert.True(t, h.Empty())
+
+	h.Insert(1)
+	assert.Eq(t, h.Count(), 1)
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	h := FromSlice([]int{5, 2, 4, 1, 3}, 4, ascending)
+
+	i := 1
+	h.ForEach(func(val int) {
+		assert.Eq(t, val, i)
+		i++
+	})
+
+	h.Insert(0)
+	assert.Eq(t, h.Extract(), 0)
+	assert.Eq(t, h.Extract(), 1)
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	h := FromSlice([]int{1, 2, 3}, 4, ascending)
+
+	c := h.Clone()
+	assert.True(t, c.Equal(h))
+	assert.Eq(t, c.Arity(), 4)
+
+	c.Extract()
+	h.Insert(4)
+
+	assert.False(t, c.Equal(h))
+	assert.Eq(t, h.Peek(), 1)
+	assert.Eq(t, c.Peek(), 2)
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := FromSlice([]int{1, 2, 2}, 2, ascending)
+	b := FromSlice([]int{2, 1, 2}, 4, ascending)
+	assert.True(t, a.Equal(b))
+
+	b.Extract()
+	b.Insert(2)
+	assert.False(t, a.Equal(b))
+}
+
+func benchmarkInsertAndExtract(b *testing.B, d int) {
+	h := New(d, ascending)
+
+	for x := 0; x < 1_000_000; x++ {
+		h.Insert(x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		h.Insert(h.Extract() + 1_000_000)
+	}
+}
+
+func BenchmarkDaryHeap2(b *testing.B) { benchmarkInsertAndExtract(b, 2) }
+func BenchmarkDaryHeap4(b *testing.B) { benchmarkInsertAndExtract(b, 4) }
+func BenchmarkDaryHeap8(b *testing.B) { benchmarkInsertAndExtract(b, 8) }
@@ -0,0 +1,332 @@
+package expiringmap
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nomad-software/goad/binaryheap"
+	"github.com/nomad-software/goad/hashmap"
+)
+
+// Entry is a value of the map along with the time it expires.
+type entry[V comparable] struct {
+	val     V
+	expires int64
+}
+
+// Deadline is the time a key expires, as held in the expiry heap. Putting a
+// key again leaves its old deadline in the heap, which is ignored once reached
+// because it no longer matches the entry.
+type deadline[K comparable] struct {
+	key     K
+	expires int64
+}
+
+// ExpiringMap is a map whose entries expire after a time to live.
+// Expired entries are never returned. They are removed lazily when they are
+// next looked up, when the map is swept, and by a background sweeper if one
+// has been started. An expiring map is safe for concurrent use.
+type ExpiringMap[K comparable, V comparable] struct {
+	mu       sync.Mutex
+	data     hashmap.HashMap[K, entry[V]]
+	expiry   binaryheap.BinaryHeap[deadline[K]]
+	ttl      time.Duration
+	now      func() time.Time
+	sweeping chan struct{}
+	swept    chan struct{}
+}
+
+// New is used to create a new expiring map whose entries expire after the
+// passed time to live by default.
+func New[K comparable, V comparable](ttl time.Duration) *ExpiringMap[K, V] {
+	return NewWithClock[K, V](ttl, time.Now)
+}
+
+// NewWithClock is used to create a new expiring map that reads the time from
+// the passed clock, allowing time to be controlled in tests.
+func NewWithClock[K comparable, V comparable](ttl time.Duration, now func() time.Time) *ExpiringMap[K, V] {
+	if ttl <= 0 {
+		panic("time to live must be greater than zero")
+	}
+
+	return &ExpiringMap[K, V]{
+		data: hashmap.New[K, entry[V]](),
+		expiry: binaryheap.New(func(a deadline[K], b deadline[K]) bool {
+			return a.expires < b.expires
+		}),
+		ttl: ttl,
+		now: now,
+	}
+}
+
+// TTL returns the default time to live of entries.
+func (m *ExpiringMap[K, V]) TTL() time.Duration {
+	return m.ttl
+}
+
+// Count returns the amount of entries in the map that haven't expired.
+func (m *ExpiringMap[K, V]) Count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep()
+	return m.data.Count()
+}
+
+// Empty returns true if the map is empty, false if not.
+func (m *ExpiringMap[K, V]) Empty() bool {
+	return m.Count() == 0
+}
+
+// Put adds a value to the map relating to the passed key, which expires after
+// the default time to live.
+func (m *ExpiringMap[K, V]) Put(key K, val V) {
+	m.PutWithTTL(key, val, m.ttl)
+}
+
+// PutWithTTL adds a value to the map relating to the passed key, which expires
+// after the passed time to live.
+func (m *ExpiringMap[K, V]) PutWithTTL(key K, val V, ttl time.Duration) {
+	if ttl <= 0 {
+		panic("time to live must be greater than zero")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expires := m.now().Add(ttl).UnixNano()
+	m.data.Put(key, entry[V]{val: val, expires: expires})
+	m.expiry.Insert(deadline[K]{key: key, expires: expires})
+	m.compact()
+}
+
+// Lookup returns the entry relating to the passed key, removing it if it has
+// expired.
+func (m *ExpiringMap[K, V]) lookup(key K) (entry[V], bool) {
+	e, ok := m.data.Get(key)
+	if ok && e.expires <= m.now().UnixNano() {
+		m.data.Remove(key)
+		return e, false
+	}
+	return e, ok
+}
+
+// Get gets a value from the map relating to the passed key.
+func (m *ExpiringMap[K, V]) Get(key K) (val V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.lookup(key)
+	if !ok {
+		return val, false
+	}
+	return e.val, true
+}
+
+// Expires returns the time the entry relating to the passed key expires, or
+// false if the key isn't present.
+func (m *ExpiringMap[K, V]) Expires(key K) (time.Time, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.lookup(key)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, e.expires), true
+}
+
+// Remove deletes a value from the map relating to the passed key. It returns
+// the removed value and true, or the zero value and false if the key wasn't
+// present or had expired.
+func (m *ExpiringMap[K, V]) Remove(key K) (val V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.data.Remove(key)
+	if !ok || e.expires <= m.now().UnixNano() {
+		return val, false
+	}
+	return e.val, true
+}
+
+// ContainsKey returns true if the passed key is present, false if not.
+func (m *ExpiringMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Sweep removes every expired entry, returning the amount removed.
+func (m *ExpiringMap[K, V]) Sweep() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sweep()
+}
+
+// Sweep removes every expired entry, returning the amount removed. Deadlines
+// are extracted from the heap in order, so only expired deadlines are visited.
+func (m *ExpiringMap[K, V]) sweep() int {
+	var removed int
+	now := m.now().UnixNano()
+
+	for !m.expiry.Empty() && m.expiry.Peek().expires <= now {
+		d := m.expiry.Extract()
+
+		// Skip deadlines left behind by putting the key again, or by
+		// removing it.
+		m.data.Compute(d.key, func(e entry[V], ok bool) (entry[V], bool) {
+			if ok && e.expires == d.expires {
+				removed++
+				return e, false
+			}
+			return e, ok
+		})
+	}
+
+	m.compact()
+	return removed
+}
+
+// Compact rebuilds the expiry heap from the entries if stale deadlines, left
+// behind by putting keys again or removing them, greatly outnumber the
+// entries. Otherwise they stay in the heap until they are reached.
+func (m *ExpiringMap[K, V]) compact() {
+	if m.expiry.Count() <= 2*m.data.Count()+64 {
+		return
+	}
+
+	deadlines := make([]deadline[K], 0, m.data.Count())
+	m.data.ForEach(func(key K, e entry[V]) {
+		deadlines = append(deadlines, deadline[K]{key: key, expires: e.expires})
+	})
+
+	m.expiry.Clear()
+	m.expiry.InsertAll(deadlines...)
+}
+
+// StartSweeping starts a background goroutine that sweeps the map at the
+// passed interval, until StopSweeping is called.
+func (m *ExpiringMap[K, V]) StartSweeping(interval time.Duration) {
+	if interval <= 0 {
+		panic("sweeping interval must be greater than zero")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.sweeping != nil {
+		panic("sweeping already started")
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	m.sweeping, m.swept = stop, done
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				m.Sweep()
+			}
+		}
+	}()
+}
+
+// StopSweeping stops the background sweeper, waiting for any sweep in progress
+// to finish. It does nothing if the sweeper isn't running.
+func (m *ExpiringMap[K, V]) StopSweeping() {
+	m.mu.Lock()
+	stop, done := m.sweeping, m.swept
+	m.sweeping, m.swept = nil, nil
+	m.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// Clear empties the entire map.
+func (m *ExpiringMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.data.Clear()
+	m.expiry.Clear()
+}
+
+// ForEach iterates over the entries within the map that haven't expired,
+// calling the passed function for each value. The map is locked while
+// iterating, so the passed function must not use the map.
+func (m *ExpiringMap[K, V]) ForEach(f func(key K, val V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep()
+	m.data.ForEach(func(key K, e entry[V]) {
+		f(key, e.val)
+	})
+}
+
+// ToMap returns a map containing the keys and values of the entries that
+// haven't expired.
+func (m *ExpiringMap[K, V]) ToMap() map[K]V {
+	vals := make(map[K]V)
+	m.ForEach(func(key K, val V) {
+		vals[key] = val
+	})
+	return vals
+}
+
+// Clone returns a copy of the map that shares no entries with the original.
+// The copy uses the same clock, but doesn't sweep in the background even if
+// the original does.
+func (m *ExpiringMap[K, V]) Clone() *ExpiringMap[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep()
+
+	return &ExpiringMap[K, V]{
+		data:   m.data.Clone(),
+		expiry: m.expiry.Clone(),
+		ttl:    m.ttl,
+		now:    m.now,
+	}
+}
+
+// Equal returns true if both maps contain the same unexpired keys relating to
+// the same values, false if not. The times the entries expire are ignored.
+func (m *ExpiringMap[K, V]) Equal(other *ExpiringMap[K, V]) bool {
+	if m == other {
+		return true
+	}
+
+	// Take a copy of the other map first, so only one map is ever locked.
+	vals := other.ToMap()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep()
+	if m.data.Count() != len(vals) {
+		return false
+	}
+
+	result := true
+	m.data.ForEach(func(key K, e entry[V]) {
+		if v, ok := vals[key]; !ok || v != e.val {
+			result = false
+		}
+	})
+
+	return result
+}
@@ -0,0 +1,400 @@
+package expiringmap
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nomad-software/assert"
+)
+
+// Clock is a fake clock that only moves when advanced.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewClock returns a fake clock set to an arbitrary time.
+func newClock() *clock {
+	return &clock{now: time.Unix(1_000_000, 0)}
+}
+
+// Now returns the time of the clock.
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by the passed duration.
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int](time.Minute)
+	assert.True(t, m.Empty())
+	assert.Eq(t, m.TTL(), time.Minute)
+
+	m.Put("foo", 1)
+	m.Put("bar", 2)
+	assert.Eq(t, m.Count(), 2)
+
+	val, ok := m.Get("foo")
+	assert.True(t, ok)
+	assert.Eq(t, val, 1)
+}
+
+func TestFailedNew(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	New[string, int](0)
+}
+
+func TestFailedPutWithTTL(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	m := New[string, int](time.Minute)
+	m.PutWithTTL("foo", 1, -time.Second)
+}
+
+func TestExpiry(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := NewWithClock[string, int](time.Minute, c.Now)
+	m.Put("foo", 1)
+
+	c.Advance(time.Minute - time.Nanosecond)
+	assert.True(t, m.ContainsKey("foo"))
+
+	// Entries expire exactly at their deadline.
+	c.Advance(time.Nanosecond)
+	_, ok := m.Get("foo")
+	assert.False(t, ok)
+	assert.True(t, m.Empty())
+}
+
+func TestPutWithTTL(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := NewWithClock[string, int](time.Minute, c.Now)
+	m.Put("foo", 1)
+	m.PutWithTTL("bar", 2, time.Second)
+	m.PutWithTTL("baz", 3, time.Hour)
+
+	c.Advance(time.Second)
+	assert.Eq(t, m.Count(), 2)
+	assert.False(t, m.ContainsKey("bar"))
+
+	c.Advance(time.Minute)
+	assert.Eq(t, m.Count(), 1)
+	assert.True(t, m.ContainsKey("baz"))
+}
+
+func TestPutAgain(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := NewWithClock[string, int](time.Minute, c.Now)
+	m.Put("foo", 1)
+
+	c.Advance(30 * time.Second)
+	m.Put("foo", 2)
+
+	// The first deadline is reached but no longer matches the entry.
+	c.Advance(30 * time.Second)
+	assert.Eq(t, m.Sweep(), 0)
+
+	val, ok := m.Get("foo")
+	assert.True(t, ok)
+	assert.Eq(t, val, 2)
+
+	c.Advance(30 * time.Second)
+	assert.Eq(t, m.Sweep(), 1)
+	assert.True(t, m.Empty())
+}
+
+func TestExpires(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := NewWithClock[string, int](time.Minute, c.Now)
+	m.Put("foo", 1)
+
+	expires, ok := m.Expires("foo")
+	assert.True(t, ok)
+	assert.True(t, expires.Equal(c.Now().Add(time.Minute)))
+
+	_, ok = m.Expires("bar")
+	assert.False(t, ok)
+
+	c.Advance(time.Minute)
+	_, ok = m.Expires("foo")
+	assert.False(t, ok)
+}
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := NewWithClock[string, int](time.Minute, c.Now)
+	m.Put("foo", 1)
+	m.Put("bar", 2)
+
+	val, ok := m.Remove("foo")
+	assert.True(t, ok)
+	assert.Eq(t, val, 1)
+
+	_, ok = m.Remove("foo")
+	assert.False(t, ok)
+
+	// Removing an expired entry reports it as absent.
+	c.Advance(time.Minute)
+	_, ok = m.Remove("bar")
+	assert.False(t, ok)
+	assert.True(t, m.Empty())
+}
+
+func TestSweep(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := NewWithClock[int, int](time.Minute, c.Now)
+	for i := 0; i < 10; i++ {
+		m.PutWithTTL(i, i, time.Duration(i+1)*time.Second)
+	}
+
+	c.Advance(5 * time.Second)
+	assert.Eq(t, m.Sweep(), 5)
+	assert.Eq(t, m.Sweep(), 0)
+	assert.Eq(t, m.data.Count(), 5)
+	assert.Eq(t, m.expiry.Count(), 5)
+}
+
+func TestSweepCompaction(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := NewWithClock[int, int](time.Minute, c.Now)
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	for i := 0; i < 99; i++ {
+		m.Remove(i)
+	}
+	assert.Eq(t, m.expiry.Count(), 100)
+
+	// The deadlines of removed keys are dropped once they greatly outnumber
+	// the entries, without waiting for them to be reached.
+	assert.Eq(t, m.Sweep(), 0)
+	assert.Eq(t, m.expiry.Count(), 1)
+
+	for i := 0; i < 100; i++ {
+		m.Put(-1, i)
+	}
+	assert.True(t, m.expiry.Count() <= 67)
+
+	c.Advance(time.Minute)
+	assert.Eq(t, m.Sweep(), 2)
+	assert.Eq(t, m.expiry.Count(), 0)
+}
+
+func TestSweeping(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := NewWithClock[string, int](time.Minute, c.Now)
+	m.Put("foo", 1)
+
+	m.StartSweeping(time.Millisecond)
+	defer m.StopSweeping()
+
+	c.Advance(time.Minute)
+
+	for i := 0; i < 1_000; i++ {
+		m.mu.Lock()
+		n := m.data.Count()
+		m.mu.Unlock()
+		if n == 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("entry was never swept")
+}
+
+func TestStopSweeping(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int](time.Minute)
+	m.StopSweeping()
+
+	m.StartSweeping(time.Millisecond)
+	m.StopSweeping()
+	m.StopSweeping()
+
+	m.StartSweeping(time.Millisecond)
+	m.StopSweeping()
+}
+
+func TestFailedStartSweeping(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int](time.Minute)
+	m.StartSweeping(time.Hour)
+	defer m.StopSweeping()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	m.StartSweeping(time.Hour)
+}
+
+func TestFailedStartSweepingInterval(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int](time.Minute)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+		// Nothing was started, so sweeping can still be started afterwards.
+		m.StartSweeping(time.Hour)
+		m.StopSweeping()
+	}()
+
+	m.StartSweeping(0)
+}
+
+func TestClear(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int](time.Minute)
+	m.Put("foo", 1)
+	m.Put("bar", 2)
+
+	m.Clear()
+	assert.True(t, m.Empty())
+	assert.Eq(t, m.expiry.Count(), 0)
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := NewWithClock[int, int](time.Minute, c.Now)
+	for i := 0; i < 10; i++ {
+		m.Put(i, i)
+	}
+	c.Advance(time.Second)
+	for i := 10; i < 20; i++ {
+		m.Put(i, i)
+	}
+	c.Advance(time.Minute - time.Second)
+
+	var sum int
+	m.ForEach(func(key int, val int) {
+		assert.Eq(t, key, val)
+		sum += val
+	})
+	assert.Eq(t, sum, 145)
+
+	vals := m.ToMap()
+	assert.Eq(t, len(vals), 10)
+	assert.Eq(t, vals[19], 19)
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := NewWithClock[string, int](time.Minute, c.Now)
+	m.Put("foo", 1)
+	m.Put("bar", 2)
+
+	n := m.Clone()
+	assert.Eq(t, n.TTL(), time.Minute)
+	assert.True(t, n.Equal(m))
+
+	n.Put("foo", 3)
+	m.Remove("bar")
+
+	val, _ := m.Get("foo")
+	assert.Eq(t, val, 1)
+	assert.True(t, n.ContainsKey("bar"))
+	assert.False(t, n.Equal(m))
+
+	// The copy uses the same clock.
+	c.Advance(time.Minute)
+	assert.True(t, n.Empty())
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	a := NewWithClock[string, int](time.Minute, c.Now)
+	b := NewWithClock[string, int](time.Hour, c.Now)
+	assert.True(t, a.Equal(b))
+
+	a.Put("foo", 1)
+	a.PutWithTTL("bar", 2, time.Second)
+	b.Put("foo", 1)
+	assert.False(t, a.Equal(b))
+	assert.True(t, a.Equal(a))
+
+	// Expired entries are ignored, as are the times entries expire.
+	c.Advance(time.Second)
+	assert.True(t, a.Equal(b))
+	assert.True(t, b.Equal(a))
+
+	b.Put("foo", 2)
+	assert.False(t, a.Equal(b))
+}
+
+func BenchmarkExpiringMapPut(b *testing.B) {
+	m := New[int, int](time.Minute)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Put(x%1_000, x)
+	}
+}
+
+func BenchmarkExpiringMapGet(b *testing.B) {
+	m := New[int, int](time.Minute)
+	for i := 0; i < 1_000; i++ {
+		m.Put(i, i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Get(x % 1_000)
+	}
+}
@@ -0,0 +1,696 @@
+package graph
+
+import (
+	"github.com/nomad-software/goad/binaryheap"
+	"github.com/nomad-software/goad/hashmap"
+	"github.com/nomad-software/goad/queue"
+	"github.com/nomad-software/goad/set"
+	"github.com/nomad-software/goad/stack"
+	"github.com/nomad-software/goad/unionfind"
+	"golang.org/x/exp/constraints"
+)
+
+// Weight is the constraint for the type of edge weights.
+type Weight interface {
+	constraints.Integer | constraints.Float
+}
+
+// Edge is a weighted edge between two vertices.
+type Edge[T comparable, W Weight] struct {
+	From   T
+	To     T
+	Weight W
+}
+
+// Vertex holds the adjacency of a single vertex.
+// Undirected graphs only use the outgoing map, directed graphs also track the
+// incoming edges so that the graph can be walked in reverse.
+type vertex[T comparable, W Weight] struct {
+	out hashmap.HashMap[T, W]
+	in  hashmap.HashMap[T, W]
+}
+
+// Graph is the main graph type.
+// The vertices are held in a hash map shared by any copy made by assignment,
+// while the edge count is not, so edges added through one copy appear in both
+// but are only counted in one. Clone copies every vertex.
+type Graph[T comparable, W Weight] struct {
+	directed bool
+	vertices hashmap.HashMap[T, *vertex[T, W]]
+	edges    int
+}
+
+// NewDirected is used to create a new directed graph.
+func NewDirected[T comparable, W Weight]() Graph[T, W] {
+	return Graph[T, W]{
+		directed: true,
+		vertices: hashmap.New[T, *vertex[T, W]](),
+	}
+}
+
+// NewUndirected is used to create a new undirected graph.
+func NewUndirected[T comparable, W Weight]() Graph[T, W] {
+	return Graph[T, W]{
+		directed: false,
+		vertices: hashmap.New[T, *vertex[T, W]](),
+	}
+}
+
+// Directed returns true if the graph is directed, false if not.
+func (g Graph[T, W]) Directed() bool {
+	return g.directed
+}
+
+// VertexCount returns the amount of vertices in the graph.
+func (g Graph[T, W]) VertexCount() int {
+	return g.vertices.Count()
+}
+
+// EdgeCount returns the amount of edges in the graph.
+func (g Graph[T, W]) EdgeCount() int {
+	return g.edges
+}
+
+// Empty returns true if the graph has no vertices, false if not.
+func (g Graph[T, W]) Empty() bool {
+	return g.VertexCount() == 0
+}
+
+// AddVertex adds a vertex to the graph if it doesn't already exist.
+func (g *Graph[T, W]) AddVertex(v T) {
+	if !g.vertices.ContainsKey(v) {
+		g.vertices.Put(v, &vertex[T, W]{
+			out: hashmap.New[T, W](),
+			in:  hashmap.New[T, W](),
+		})
+	}
+}
+
+// HasVertex returns true if the vertex exists in the graph, false if not.
+func (g Graph[T, W]) HasVertex(v T) bool {
+	return g.vertices.ContainsKey(v)
+}
+
+// RemoveVertex removes a vertex and all of its edges from the graph.
+func (g *Graph[T, W]) RemoveVertex(v T) {
+	vx, ok := g.vertices.Get(v)
+	if !ok {
+		return
+	}
+
+	out := make([]T, 0, vx.out.Count())
+	vx.out.ForEach(func(to T, w W) {
+		out = append(out, to)
+	})
+	for _, to := range out {
+		g.RemoveEdge(v, to)
+	}
+
+	in := make([]T, 0, vx.in.Count())
+	vx.in.ForEach(func(from T, w W) {
+		in = append(in, from)
+	})
+	for _, from := range in {
+		g.RemoveEdge(from, v)
+	}
+
+	g.vertices.Remove(v)
+}
+
+// AddEdge adds an edge between two vertices, adding the vertices if they
+// don't already exist. If the edge already exists its weight is updated.
+func (g *Graph[T, W]) AddEdge(from T, to T, weight W) {
+	g.AddVertex(from)
+	g.AddVertex(to)
+
+	f, _ := g.vertices.Get(from)
+	t, _ := g.vertices.Get(to)
+
+	if !f.out.ContainsKey(to) {
+		g.edges++
+	}
+
+	f.out.Put(to, weight)
+
+	if g.directed {
+		t.in.Put(from, weight)
+	} else {
+		t.out.Put(from, weight)
+	}
+}
+
+// HasEdge returns true if the edge exists in the graph, false if not.
+func (g Graph[T, W]) HasEdge(from T, to T) bool {
+	f, ok := g.vertices.Get(from)
+	if !ok {
+		return false
+	}
+	return f.out.ContainsKey(to)
+}
+
+// Weight returns the weight of the edge between two vertices.
+func (g Graph[T, W]) Weight(from T, to T) (weight W, ok bool) {
+	f, ok := g.vertices.Get(from)
+	if !ok {
+		return weight, false
+	}
+	return f.out.Get(to)
+}
+
+// RemoveEdge removes the edge between two vertices.
+func (g *Graph[T, W]) RemoveEdge(from T, to T) {
+	f, ok := g.vertices.Get(from)
+	if !ok || !f.out.ContainsKey(to) {
+		return
+	}
+
+	t, _ := g.vertices.Get(to)
+
+	f.out.Remove(to)
+
+	if g.directed {
+		t.in.Remove(from)
+	} else {
+		t.out.Remove(from)
+	}
+
+	g.edges--
+}
+
+// OutDegree returns the amount of edges leaving the passed vertex. For
+// undirected graphs this is the degree of the vertex.
+func (g Graph[T, W]) OutDegree(v T) int {
+	return g.mustGet(v).out.Count()
+}
+
+// InDegree returns the amount of edges entering the passed vertex. For
+// undirected graphs this is the degree of the vertex.
+func (g Graph[T, W]) InDegree(v T) int {
+	if !g.directed {
+		return g.OutDegree(v)
+	}
+	return g.mustGet(v).in.Count()
+}
+
+// Clear empties the entire graph.
+func (g *Graph[T, W]) Clear() {
+	g.vertices.Clear()
+	g.edges = 0
+}
+
+// ForEachVertex iterates over the vertices within the graph, calling the
+// passed function for each vertex.
+func (g Graph[T, W]) ForEachVertex(f func(v T)) {
+	g.vertices.ForEach(func(v T, vx *vertex[T, W]) {
+		f(v)
+	})
+}
+
+// ForEachNeighbour iterates over the vertices reachable by a single edge from
+// the passed vertex, calling the passed function for each one.
+func (g Graph[T, W]) ForEachNeighbour(v T, f func(to T, weight W)) {
+	g.mustGet(v).out.ForEach(f)
+}
+
+// ForEachEdge iterates over the edges within the graph, calling the passed
+// function for each edge. Undirected edges are only visited once.
+func (g Graph[T, W]) ForEachEdge(f func(e Edge[T, W])) {
+	seen := set.New[T]()
+
+	g.vertices.ForEach(func(from T, vx *vertex[T, W]) {
+		vx.out.ForEach(func(to T, w W) {
+			if g.directed || !seen.Contains(to) {
+				f(Edge[T, W]{From: from, To: to, Weight: w})
+			}
+		})
+		seen.Add(from)
+	})
+}
+
+// Clone returns a copy of the graph that shares no storage with the original.
+func (g Graph[T, W]) Clone() Graph[T, W] {
+	c := Graph[T, W]{
+		directed: g.directed,
+		vertices: hashmap.New[T, *vertex[T, W]](),
+		edges:    g.edges,
+	}
+
+	g.vertices.ForEach(func(v T, vx *vertex[T, W]) {
+		c.vertices.Put(v, &vertex[T, W]{
+			out: vx.out.Clone(),
+			in:  vx.in.Clone(),
+		})
+	})
+
+	return c
+}
+
+// Equal returns true if both graphs have the same direction, vertices and
+// weighted edges, false if not.
+func (g Graph[T, W]) Equal(other Graph[T, W]) bool {
+	if g.directed != other.directed || g.VertexCount() != other.VertexCount() || g.EdgeCount() != other.EdgeCount() {
+		return false
+	}
+
+	result := true
+	g.vertices.ForEach(func(v T, vx *vertex[T, W]) {
+		if o, ok := other.vertices.Get(v); !ok || !vx.out.Equal(o.out) {
+			result = false
+		}
+	})
+
+	return result
+}
+
+// MustGet returns the passed vertex or panics if it doesn't exist.
+func (g Graph[T, W]) mustGet(v T) *vertex[T, W] {
+	vx, ok := g.vertices.Get(v)
+	if !ok {
+		panic("vertex not found in graph")
+	}
+	return vx
+}
+
+// BFS performs a breadth first traversal starting at the passed vertex,
+// calling the passed function for each vertex reached.
+func (g Graph[T, W]) BFS(start T, f func(v T)) {
+	g.mustGet(start)
+
+	visited := set.New[T]()
+	q := queue.New[T]()
+
+	visited.Add(start)
+	q.Enqueue(start)
+
+	for !q.Empty() {
+		v := q.Dequeue()
+		f(v)
+
+		g.mustGet(v).out.ForEach(func(to T, w W) {
+			if !visited.Contains(to) {
+				visited.Add(to)
+				q.Enqueue(to)
+			}
+		})
+	}
+}
+
+// DFS performs a depth first traversal starting at the passed vertex, calling
+// the passed function for each vertex reached.
+func (g Graph[T, W]) DFS(start T, f func(v T)) {
+	g.mustGet(start)
+
+	visited := set.New[T]()
+	s := stack.New[T]()
+
+	s.Push(start)
+
+	for !s.Empty() {
+		v := s.Pop()
+		if visited.Contains(v) {
+			continue
+		}
+
+		visited.Add(v)
+		f(v)
+
+		g.mustGet(v).out.ForEach(func(to T, w W) {
+			if !visited.Contains(to) {
+				s.Push(to)
+			}
+		})
+	}
+}
+
+// TopologicalSort returns the vertices of a directed graph ordered so that
+// every edge leads from an earlier vertex to a later one. If the graph
+// contains a cycle no such order exists and false is returned.
+func (g Graph[T, W]) TopologicalSort() ([]T, bool) {
+	if !g.directed {
+		panic("topological sort requires a directed graph")
+	}
+
+	degree := hashmap.New[T, int]()
+	q := queue.New[T]()
+
+	g.vertices.ForEach(func(v T, vx *vertex[T, W]) {
+		degree.Put(v, vx.in.Count())
+		if vx.in.Empty() {
+			q.Enqueue(v)
+		}
+	})
+
+	result := make([]T, 0, g.VertexCount())
+
+	for !q.Empty() {
+		v := q.Dequeue()
+		result = append(result, v)
+
+		g.mustGet(v).out.ForEach(func(to T, w W) {
+			d, _ := degree.Get(to)
+			degree.Put(to, d-1)
+			if d-1 == 0 {
+				q.Enqueue(to)
+			}
+		})
+	}
+
+	if len(result) != g.VertexCount() {
+		return nil, false
+	}
+
+	return result, true
+}
+
+// Components returns the connected components of the graph. Edge direction is
+// ignored, so for directed graphs these are the weakly connected components.
+func (g Graph[T, W]) Components() [][]T {
+	visited := set.New[T]()
+	result := make([][]T, 0)
+
+	g.vertices.ForEach(func(start T, _ *vertex[T, W]) {
+		if visited.Contains(start) {
+			return
+		}
+
+		component := make([]T, 0)
+		s := stack.New[T]()
+		s.Push(start)
+		visited.Add(start)
+
+		for !s.Empty() {
+			v := s.Pop()
+			component = append(component, v)
+
+			push := func(to T, w W) {
+				if !visited.Contains(to) {
+					visited.Add(to)
+					s.Push(to)
+				}
+			}
+
+			vx := g.mustGet(v)
+			vx.out.ForEach(push)
+			vx.in.ForEach(push)
+		}
+
+		result = append(result, component)
+	})
+
+	return result
+}
+
+// Visit is a stack frame used when walking the graph depth first where the
+// vertex needs processing again once all of its descendants are finished.
+type visit[T comparable] struct {
+	v        T
+	finished bool
+}
+
+// StronglyConnectedComponents returns the strongly connected components of
+// the graph, using Kosaraju's algorithm. For undirected graphs these are the
+// same as the connected components.
+func (g Graph[T, W]) StronglyConnectedComponents() [][]T {
+	if !g.directed {
+		return g.Components()
+	}
+
+	visited := set.New[T]()
+	order := stack.New[T]()
+
+	g.vertices.ForEach(func(start T, _ *vertex[T, W]) {
+		s := stack.New[visit[T]]()
+		s.Push(visit[T]{v: start})
+
+		for !s.Empty() {
+			frame := s.Pop()
+
+			if frame.finished {
+				order.Push(frame.v)
+				continue
+			}
+
+			if visited.Contains(frame.v) {
+				continue
+			}
+
+			visited.Add(frame.v)
+			s.Push(visit[T]{v: frame.v, finished: true})
+
+			g.mustGet(frame.v).out.ForEach(func(to T, w W) {
+				if !visited.Contains(to) {
+					s.Push(visit[T]{v: to})
+				}
+			})
+		}
+	})
+
+	visited.Clear()
+	result := make([][]T, 0)
+
+	for !order.Empty() {
+		start := order.Pop()
+		if visited.Contains(start) {
+			continue
+		}
+
+		component := make([]T, 0)
+		s := stack.New[T]()
+		s.Push(start)
+		visited.Add(start)
+
+		for !s.Empty() {
+			v := s.Pop()
+			component = append(component, v)
+
+			g.mustGet(v).in.ForEach(func(from T, w W) {
+				if !visited.Contains(from) {
+					visited.Add(from)
+					s.Push(from)
+				}
+			})
+		}
+
+		result = append(result, component)
+	}
+
+	return result
+}
+
+// Paths holds the result of a single source shortest path search.
+type Paths[T comparable, W Weight] struct {
+	source T
+	dist   hashmap.HashMap[T, W]
+	prev   hashmap.HashMap[T, T]
+}
+
+// Source returns the vertex the paths were calculated from.
+func (p Paths[T, W]) Source() T {
+	return p.source
+}
+
+// DistanceTo returns the total weight of the shortest path to the passed
+// vertex. If the vertex is unreachable false is returned.
+func (p Paths[T, W]) DistanceTo(v T) (W, bool) {
+	return p.dist.Get(v)
+}
+
+// PathTo returns the vertices along the shortest path to the passed vertex,
+// starting with the source. If the vertex is unreachable false is returned.
+func (p Paths[T, W]) PathTo(v T) ([]T, bool) {
+	if !p.dist.ContainsKey(v) {
+		return nil, false
+	}
+
+	s := stack.New[T]()
+	s.Push(v)
+
+	for v != p.source {
+		v, _ = p.prev.Get(v)
+		s.Push(v)
+	}
+
+	result := make([]T, 0, s.Count())
+	for !s.Empty() {
+		result = append(result, s.Pop())
+	}
+
+	return result, true
+}
+
+// Distance is a heap entry used when searching for shortest paths.
+type distance[T comparable, W Weight] struct {
+	v    T
+	dist W
+}
+
+// Dijkstra returns the shortest paths from the passed vertex to every
+// reachable vertex using Dijkstra's algorithm. The graph must not contain
+// negative edge weights, use BellmanFord if it does.
+func (g Graph[T, W]) Dijkstra(source T) Paths[T, W] {
+	g.mustGet(source)
+
+	p := Paths[T, W]{
+		source: source,
+		dist:   hashmap.New[T, W](),
+		prev:   hashmap.New[T, T](),
+	}
+
+	done := set.New[T]()
+	h := binaryheap.New(func(a, b distance[T, W]) bool { return a.dist < b.dist })
+
+	p.dist.Put(source, 0)
+	h.Insert(distance[T, W]{v: source})
+
+	for !h.Empty() {
+		d := h.Extract()
+		if done.Contains(d.v) {
+			continue
+		}
+		done.Add(d.v)
+
+		g.mustGet(d.v).out.ForEach(func(to T, w W) {
+			if w < 0 {
+				panic("negative edge weight, use BellmanFord instead")
+			}
+			if done.Contains(to) {
+				return
+			}
+			dist := d.dist + w
+			if current, ok := p.dist.Get(to); !ok || dist < current {
+				p.dist.Put(to, dist)
+				p.prev.Put(to, d.v)
+				h.Insert(distance[T, W]{v: to, dist: dist})
+			}
+		})
+	}
+
+	return p
+}
+
+// BellmanFord returns the shortest paths from the passed vertex to every
+// reachable vertex using the Bellman-Ford algorithm, which supports negative
+// edge weights. If a negative cycle is reachable from the source no shortest
+// paths exist and false is returned.
+func (g Graph[T, W]) BellmanFord(source T) (Paths[T, W], bool) {
+	g.mustGet(source)
+
+	p := Paths[T, W]{
+		source: source,
+		dist:   hashmap.New[T, W](),
+		prev:   hashmap.New[T, T](),
+	}
+
+	p.dist.Put(source, 0)
+
+	relax := func(e Edge[T, W]) bool {
+		d, ok := p.dist.Get(e.From)
+		if !ok {
+			return false
+		}
+		if current, ok := p.dist.Get(e.To); !ok || d+e.Weight < current {
+			p.dist.Put(e.To, d+e.Weight)
+			p.prev.Put(e.To, e.From)
+			return true
+		}
+		return false
+	}
+
+	edges := make([]Edge[T, W], 0, g.EdgeCount()*2)
+	g.vertices.ForEach(func(from T, vx *vertex[T, W]) {
+		vx.out.ForEach(func(to T, w W) {
+			edges = append(edges, Edge[T, W]{From: from, To: to, Weight: w})
+		})
+	})
+
+	for i := 1; i < g.VertexCount(); i++ {
+		var changed bool
+		for _, e := range edges {
+			if relax(e) {
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	for _, e := range edges {
+		if relax(e) {
+			return Paths[T, W]{}, false
+		}
+	}
+
+	return p, true
+}
+
+// Kruskal returns the minimum spanning forest of an undirected graph using
+// Kruskal's algorithm. The returned graph contains every vertex of the
+// original graph.
+func (g Graph[T, W]) Kruskal() Graph[T, W] {
+	if g.directed {
+		panic("minimum spanning tree requires an undirected graph")
+	}
+
+	result := NewUndirected[T, W]()
+	sets := unionfind.New[T]()
+
+	g.vertices.ForEach(func(v T, _ *vertex[T, W]) {
+		result.AddVertex(v)
+		sets.Add(v)
+	})
+
+	h := binaryheap.New(func(a, b Edge[T, W]) bool { return a.Weight < b.Weight })
+	g.ForEachEdge(func(e Edge[T, W]) {
+		h.Insert(e)
+	})
+
+	for !h.Empty() && result.EdgeCount() < result.VertexCount()-1 {
+		e := h.Extract()
+		if sets.Union(e.From, e.To) {
+			result.AddEdge(e.From, e.To, e.Weight)
+		}
+	}
+
+	return result
+}
+
+// Prim returns the minimum spanning forest of an undirected graph using
+// Prim's algorithm. The returned graph contains every vertex of the original
+// graph.
+func (g Graph[T, W]) Prim() Graph[T, W] {
+	if g.directed {
+		panic("minimum spanning tree requires an undirected graph")
+	}
+
+	result := NewUndirected[T, W]()
+	h := binaryheap.New(func(a, b Edge[T, W]) bool { return a.Weight < b.Weight })
+
+	push := func(from T) {
+		g.mustGet(from).out.ForEach(func(to T, w W) {
+			if !result.HasVertex(to) {
+				h.Insert(Edge[T, W]{From: from, To: to, Weight: w})
+			}
+		})
+	}
+
+	g.vertices.ForEach(func(start T, _ *vertex[T, W]) {
+		if result.HasVertex(start) {
+			return
+		}
+
+		result.AddVertex(start)
+		push(start)
+
+		for !h.Empty() {
+			e := h.Extract()
+			if result.HasVertex(e.To) {
+				continue
+			}
+			result.AddEdge(e.From, e.To, e.Weight)
+			push(e.To)
+		}
+	})
+
+	return result
+}
@@ -0,0 +1,494 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
+)
+
+func sorted(vals []string) []string {
+	slices.Sort(vals)
+	return vals
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	g := NewDirected[string, int]()
+	assert.True(t, g.Empty())
+	assert.True(t, g.Directed())
+
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("a", "b", 3)
+
+	assert.False(t, g.Empty())
+	assert.Eq(t, g.VertexCount(), 3)
+	assert.Eq(t, g.EdgeCount(), 2)
+	assert.True(t, g.HasEdge("a", "b"))
+	assert.False(t, g.HasEdge("b", "a"))
+	assert.Eq(t, g.OutDegree("a"), 1)
+	assert.Eq(t, g.InDegree("a"), 0)
+	assert.Eq(t, g.InDegree("c"), 1)
+
+	w, ok := g.Weight("a", "b")
+	assert.True(t, ok)
+	assert.Eq(t, w, 3)
+
+	_, ok = g.Weight("b", "a")
+	assert.False(t, ok)
+
+	u := NewUndirected[string, int]()
+	assert.False(t, u.Directed())
+
+	u.AddEdge("a", "b", 1)
+	u.AddEdge("b", "a", 2)
+
+	assert.Eq(t, u.VertexCount(), 2)
+	assert.Eq(t, u.EdgeCount(), 1)
+	assert.True(t, u.HasEdge("a", "b"))
+	assert.True(t, u.HasEdge("b", "a"))
+
+	w, ok = u.Weight("a", "b")
+	assert.True(t, ok)
+	assert.Eq(t, w, 2)
+}
+
+func TestRemoving(t *testing.T) {
+	t.Parallel()
+
+	g := NewDirected[int, int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 1, 1)
+	g.AddEdge(3, 3, 1)
+	assert.Eq(t, g.EdgeCount(), 4)
+
+	g.RemoveEdge(1, 2)
+	g.RemoveEdge(1, 2)
+	assert.Eq(t, g.EdgeCount(), 3)
+	assert.False(t, g.HasEdge(1, 2))
+
+	g.RemoveVertex(3)
+	assert.Eq(t, g.VertexCount(), 2)
+	assert.Eq(t, g.EdgeCount(), 0)
+	assert.False(t, g.HasVertex(3))
+	assert.Eq(t, g.OutDegree(2), 0)
+	assert.Eq(t, g.InDegree(1), 0)
+
+	u := NewUndirected[int, int]()
+	u.AddEdge(1, 2, 1)
+	u.AddEdge(2, 3, 1)
+	u.AddEdge(2, 2, 1)
+	assert.Eq(t, u.EdgeCount(), 3)
+
+	u.RemoveVertex(2)
+	assert.Eq(t, u.VertexCount(), 2)
+	assert.Eq(t, u.EdgeCount(), 0)
+	assert.Eq(t, u.OutDegree(1), 0)
+	assert.Eq(t, u.OutDegree(3), 0)
+}
+
+func TestFailedDegree(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	g := NewDirected[int, int]()
+	g.OutDegree(1)
+}
+
+func TestClearing(t *testing.T) {
+	t.Parallel()
+
+	g := NewUndirected[int, int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+
+	g.Clear()
+	assert.True(t, g.Empty())
+	assert.Eq(t, g.EdgeCount(), 0)
+
+	g.AddEdge(1, 2, 1)
+	assert.Eq(t, g.VertexCount(), 2)
+	assert.Eq(t, g.EdgeCount(), 1)
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	g := NewUndirected[int, int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 2)
+	g.AddEdge(3, 3, 3)
+
+	var vertices int
+	g.ForEachVertex(func(v int) {
+		vertices++
+	})
+	assert.Eq(t, vertices, 3)
+
+	var total int
+	g.ForEachEdge(func(e Edge[int, int]) {
+		total += e.Weight
+	})
+	assert.Eq(t, total, 6)
+
+	var neighbours int
+	g.ForEachNeighbour(2, func(to int, w int) {
+		neighbours++
+	})
+	assert.Eq(t, neighbours, 2)
+}
+
+func TestBFS(t *testing.T) {
+	t.Parallel()
+
+	g := NewDirected[int, int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(2, 4, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 5, 1)
+	g.AddEdge(6, 1, 1)
+
+	depth := map[int]int{1: 0, 2: 1, 3: 1, 4: 2, 5: 3}
+	last := 0
+	visited := 0
+
+	g.BFS(1, func(v int) {
+		assert.True(t, depth[v] >= last)
+		last = depth[v]
+		visited++
+	})
+
+	assert.Eq(t, visited, 5)
+}
+
+func TestDFS(t *testing.T) {
+	t.Parallel()
+
+	g := NewDirected[int, int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 2, 1)
+	g.AddEdge(5, 1, 1)
+
+	order := make([]int, 0)
+	g.DFS(1, func(v int) {
+		order = append(order, v)
+	})
+
+	assert.Eq(t, len(order), 4)
+	assert.Eq(t, order[0], 1)
+	assert.Eq(t, order[1], 2)
+	assert.Eq(t, order[2], 3)
+	assert.Eq(t, order[3], 4)
+}
+
+func TestFailedTraversal(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	g := NewDirected[int, int]()
+	g.BFS(1, func(v int) {})
+}
+
+func TestTopologicalSort(t *testing.T) {
+	t.Parallel()
+
+	g := NewDirected[string, int]()
+	g.AddEdge("shirt", "tie", 1)
+	g.AddEdge("tie", "jacket", 1)
+	g.AddEdge("trousers", "shoes", 1)
+	g.AddEdge("trousers", "belt", 1)
+	g.AddEdge("belt", "jacket", 1)
+	g.AddEdge("socks", "shoes", 1)
+	g.AddVertex("watch")
+
+	order, ok := g.TopologicalSort()
+	assert.True(t, ok)
+	assert.Eq(t, len(order), 8)
+
+	index := make(map[string]int)
+	for i, v := range order {
+		index[v] = i
+	}
+
+	g.ForEachEdge(func(e Edge[string, int]) {
+		assert.True(t, index[e.From] < index[e.To])
+	})
+
+	g.AddEdge("jacket", "shirt", 1)
+	order, ok = g.TopologicalSort()
+	assert.False(t, ok)
+	assert.Eq(t, len(order), 0)
+}
+
+func TestComponents(t *testing.T) {
+	t.Parallel()
+
+	g := NewUndirected[string, int]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 1)
+	g.AddEdge("d", "e", 1)
+	g.AddVertex("f")
+
+	components := g.Components()
+	assert.Eq(t, len(components), 3)
+
+	found := make(map[string]bool)
+	for _, c := range components {
+		found[sorted(c)[0]] = true
+		switch sorted(c)[0] {
+		case "a":
+			assert.True(t, slices.Equal(c, []string{"a", "b", "c"}))
+		case "d":
+			assert.True(t, slices.Equal(c, []string{"d", "e"}))
+		case "f":
+			assert.True(t, slices.Equal(c, []string{"f"}))
+		}
+	}
+	assert.Eq(t, len(found), 3)
+
+	d := NewDirected[string, int]()
+	d.AddEdge("a", "b", 1)
+	d.AddEdge("c", "b", 1)
+	d.AddEdge("d", "e", 1)
+	assert.Eq(t, len(d.Components()), 2)
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	t.Parallel()
+
+	g := NewDirected[string, int]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 1)
+	g.AddEdge("c", "a", 1)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("d", "e", 1)
+	g.AddEdge("e", "f", 1)
+	g.AddEdge("f", "d", 1)
+	g.AddEdge("g", "f", 1)
+	g.AddEdge("g", "h", 1)
+	g.AddEdge("h", "g", 1)
+
+	components := g.StronglyConnectedComponents()
+	assert.Eq(t, len(components), 3)
+
+	for _, c := range components {
+		switch sorted(c)[0] {
+		case "a":
+			assert.True(t, slices.Equal(c, []string{"a", "b", "c"}))
+		case "d":
+			assert.True(t, slices.Equal(c, []string{"d", "e", "f"}))
+		case "g":
+			assert.True(t, slices.Equal(c, []string{"g", "h"}))
+		default:
+			t.Errorf("unexpected component %v", c)
+		}
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	t.Parallel()
+
+	g := NewDirected[string, float64]()
+	g.AddEdge("a", "b", 7)
+	g.AddEdge("a", "c", 9)
+	g.AddEdge("a", "f", 14)
+	g.AddEdge("b", "c", 10)
+	g.AddEdge("b", "d", 15)
+	g.AddEdge("c", "d", 11)
+	g.AddEdge("c", "f", 2)
+	g.AddEdge("d", "e", 6)
+	g.AddEdge("f", "e", 9)
+	g.AddVertex("z")
+
+	p := g.Dijkstra("a")
+	assert.Eq(t, p.Source(), "a")
+
+	d, ok := p.DistanceTo("e")
+	assert.True(t, ok)
+	assert.Eq(t, d, 20.0)
+
+	path, ok := p.PathTo("e")
+	assert.True(t, ok)
+	assert.True(t, slices.Equal(path, []string{"a", "c", "f", "e"}))
+
+	path, ok = p.PathTo("a")
+	assert.True(t, ok)
+	assert.True(t, slices.Equal(path, []string{"a"}))
+
+	_, ok = p.DistanceTo("z")
+	assert.False(t, ok)
+
+	_, ok = p.PathTo("z")
+	assert.False(t, ok)
+}
+
+func TestFailedDijkstra(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	g := NewDirected[int, int]()
+	g.AddEdge(1, 2, -1)
+	g.Dijkstra(1)
+}
+
+func TestBellmanFord(t *testing.T) {
+	t.Parallel()
+
+	g := NewDirected[string, int]()
+	g.AddEdge("s", "a", 4)
+	g.AddEdge("s", "b", 5)
+	g.AddEdge("a", "c", 3)
+	g.AddEdge("b", "a", -3)
+	g.AddEdge("c", "d", 2)
+
+	p, ok := g.BellmanFord("s")
+	assert.True(t, ok)
+
+	d, ok := p.DistanceTo("d")
+	assert.True(t, ok)
+	assert.Eq(t, d, 7)
+
+	path, ok := p.PathTo("d")
+	assert.True(t, ok)
+	assert.True(t, slices.Equal(path, []string{"s", "b", "a", "c", "d"}))
+
+	g.AddEdge("c", "b", -6)
+	_, ok = g.BellmanFord("s")
+	assert.False(t, ok)
+}
+
+func TestMinimumSpanningTree(t *testing.T) {
+	t.Parallel()
+
+	g := NewUndirected[string, int]()
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "h", 8)
+	g.AddEdge("b", "c", 8)
+	g.AddEdge("b", "h", 11)
+	g.AddEdge("c", "d", 7)
+	g.AddEdge("c", "f", 4)
+	g.AddEdge("c", "i", 2)
+	g.AddEdge("d", "e", 9)
+	g.AddEdge("d", "f", 14)
+	g.AddEdge("e", "f", 10)
+	g.AddEdge("f", "g", 2)
+	g.AddEdge("g", "h", 1)
+	g.AddEdge("g", "i", 6)
+	g.AddEdge("h", "i", 7)
+	g.AddEdge("x", "y", 3)
+
+	for _, mst := range []Graph[string, int]{g.Kruskal(), g.Prim()} {
+		assert.Eq(t, mst.VertexCount(), 11)
+		assert.Eq(t, mst.EdgeCount(), 9)
+		assert.Eq(t, len(mst.Components()), 2)
+
+		var total int
+		mst.ForEachEdge(func(e Edge[string, int]) {
+			total += e.Weight
+		})
+		assert.Eq(t, total, 40)
+	}
+}
+
+func TestFailedMinimumSpanningTree(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	g := NewDirected[int, int]()
+	g.Kruskal()
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	g := NewDirected[int, int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 2)
+
+	c := g.Clone()
+	assert.True(t, c.Equal(g))
+
+	c.RemoveEdge(1, 2)
+	c.AddEdge(2, 3, 5)
+	g.AddEdge(3, 4, 3)
+
+	assert.False(t, c.Equal(g))
+	assert.Eq(t, g.EdgeCount(), 3)
+	assert.Eq(t, c.EdgeCount(), 1)
+	assert.True(t, g.HasEdge(1, 2))
+	assert.False(t, c.HasVertex(4))
+	assert.Eq(t, g.InDegree(2), 1)
+	assert.Eq(t, c.InDegree(2), 0)
+
+	w, _ := g.Weight(2, 3)
+	assert.Eq(t, w, 2)
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := NewUndirected[int, int]()
+	b := NewUndirected[int, int]()
+	assert.True(t, a.Equal(b))
+	assert.False(t, a.Equal(NewDirected[int, int]()))
+
+	a.AddEdge(1, 2, 1)
+	b.AddEdge(2, 1, 2)
+	assert.False(t, a.Equal(b))
+
+	b.AddEdge(1, 2, 1)
+	assert.True(t, a.Equal(b))
+}
+
+func BenchmarkGraphAddEdge(b *testing.B) {
+	g := NewDirected[int, int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		g.AddEdge(x%1000, (x+1)%1000, x)
+	}
+}
+
+func BenchmarkGraphDijkstra(b *testing.B) {
+	g := NewDirected[int, int]()
+
+	for x := 0; x < 1_000; x++ {
+		g.AddEdge(x, (x+1)%1_000, 1)
+		g.AddEdge(x, (x*7)%1_000, 3)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		g.Dijkstra(0)
+	}
+}
@@ -1,28 +1,36 @@
 package hashmap
 
 import (
-	"math"
-
 	"github.com/nomad-software/goad/hash"
 	"github.com/nomad-software/goad/linkedlist"
 )
 
 const (
-	minBuckets = 16
-	loadFactor = 0.75
+	minBuckets    = 16
+	loadFactor    = 0.75
+	poolSlabSize  = 64
+	migrationStep = 8
 )
 
-// Payload is the main payload of the hash map.
+// Payload is the main payload of the hash map. The hash of the key is kept so
+// entries can be moved between buckets without hashing them again.
 type payload[K comparable, V comparable] struct {
-	key K
-	val V
+	key  K
+	val  V
+	hash uint32
 }
 
 // HashMap is the main hash map type.
+// Copying a hash map by assignment shares its buckets with the original, so
+// changes to one may corrupt the other. Use Clone to take an independent copy.
 type HashMap[K comparable, V comparable] struct {
-	capacity int
-	data     []linkedlist.LinkedList[payload[K, V]]
-	count    int
+	capacity    int
+	data        []linkedlist.LinkedList[payload[K, V]]
+	count       int
+	pool        *linkedlist.Pool[payload[K, V]]
+	incremental bool
+	old         []linkedlist.LinkedList[payload[K, V]]
+	migrated    int
 }
 
 // New is used to create a new map.
@@ -34,6 +42,37 @@ func New[K comparable, V comparable]() HashMap[K, V] {
 	}
 }
 
+// NewPooled is used to create a new map whose buckets take their nodes from a
+// pool owned by the map. Nodes of removed entries are reused by later ones and
+// resizing moves entries between buckets without allocating, so a map that
+// stays around the same size stops allocating after warming up.
+func NewPooled[K comparable, V comparable]() HashMap[K, V] {
+	m := HashMap[K, V]{
+		capacity: minBuckets,
+		pool:     linkedlist.NewPool[payload[K, V]](poolSlabSize),
+	}
+	m.data = m.buckets(minBuckets)
+	return m
+}
+
+// NewIncremental is used to create a new map that resizes incrementally.
+// Instead of moving every entry at once, a resize keeps the old buckets and
+// every following Put or Remove moves a few of them, so no single operation
+// stalls on a large map. Lookups don't move any buckets.
+func NewIncremental[K comparable, V comparable]() HashMap[K, V] {
+	m := New[K, V]()
+	m.incremental = true
+	return m
+}
+
+// FromMap is used to create a new hash map containing the keys and values of
+// the passed map.
+func FromMap[K comparable, V comparable](vals map[K]V) HashMap[K, V] {
+	m := New[K, V]()
+	m.PutMap(vals)
+	return m
+}
+
 // Count returns the amount of entries in the map.
 func (m HashMap[K, V]) Count() int {
 	return m.count
@@ -44,125 +83,513 @@ func (m HashMap[K, V]) Empty() bool {
 	return m.Count() == 0
 }
 
+// Buckets returns the passed number of empty buckets, each using the pool if
+// the map has one.
+func (m HashMap[K, V]) buckets(cap int) []linkedlist.LinkedList[payload[K, V]] {
+	data := make([]linkedlist.LinkedList[payload[K, V]], cap)
+	if m.pool != nil {
+		for i := range data {
+			data[i] = linkedlist.NewPooled(m.pool)
+		}
+	}
+	return data
+}
+
 // Resize reallocates and resizes the underlying array to the passed number of
-// buckets.
+// buckets. Any incremental resize already in progress is finished first.
 func (m *HashMap[K, V]) resize(cap int) {
 	if cap < minBuckets {
 		panic("can only resize greater or equal to the minimum bucket size")
 	}
 
-	data := m.data
+	if cap&(cap-1) != 0 {
+		panic("can only resize to a power of two bucket size")
+	}
+
+	m.migrate(len(m.old))
 
+	data := m.data
 	m.capacity = cap
-	m.data = make([]linkedlist.LinkedList[payload[K, V]], m.capacity)
-	m.count = 0
+	m.data = m.buckets(m.capacity)
 
-	for _, ln := range data {
-		ln.ForEach(func(i int, p payload[K, V]) {
-			m.Put(p.key, p.val)
-		})
+	if m.incremental {
+		m.old = data
+		m.migrated = 0
+		return
+	}
+
+	for i := range data {
+		m.rehash(&data[i])
 	}
 }
 
-// Put adds a value to the hash map relating to the passed key.
-func (m *HashMap[K, V]) Put(key K, val V) {
+// Rehash moves every entry of the passed old bucket into the bucket it
+// belongs in. The nodes themselves are moved so nothing is allocated.
+func (m *HashMap[K, V]) rehash(ln *linkedlist.LinkedList[payload[K, V]]) {
+	for !ln.Empty() {
+		ln.MoveFirst(&m.data[bucket(ln.First().hash, m.capacity)])
+	}
+}
+
+// Migrate rehashes up to n of the old buckets left by an incremental resize.
+func (m *HashMap[K, V]) migrate(n int) {
+	if m.old == nil {
+		return
+	}
+
+	for ; n > 0 && m.migrated < len(m.old); n-- {
+		m.rehash(&m.old[m.migrated])
+		m.migrated++
+	}
+
+	if m.migrated == len(m.old) {
+		m.old = nil
+		m.migrated = 0
+	}
+}
+
+// Bucket returns the index of the bucket the passed hash belongs in, out of
+// the passed number of buckets, which is always a power of two. Only the low
+// bits of the hash select the bucket, so it's mixed first to spread hashes
+// that only differ in their high bits.
+func bucket(hash uint32, buckets int) int {
+	return int(mix(hash) & uint32(buckets-1))
+}
+
+// Mix is the MurmurHash3 finaliser, which makes every bit of the hash affect
+// every bit of the result.
+func mix(hash uint32) uint32 {
+	hash ^= hash >> 16
+	hash *= 0x85ebca6b
+	hash ^= hash >> 13
+	hash *= 0xc2b2ae35
+	hash ^= hash >> 16
+	return hash
+}
+
+// Chain returns the bucket entries with the passed hash belong in. While an
+// incremental resize is in progress this may be one of the old buckets that
+// hasn't been migrated yet, in which case new entries belong there too.
+func (m HashMap[K, V]) chain(hash uint32) *linkedlist.LinkedList[payload[K, V]] {
+	if m.old != nil {
+		if b := bucket(hash, len(m.old)); b >= m.migrated {
+			return &m.old[b]
+		}
+	}
+	return &m.data[bucket(hash, m.capacity)]
+}
+
+// Find walks the bucket the passed key belongs in, stopping at the first
+// match. It returns the bucket, the position of the key within it and its
+// value.
+func (m HashMap[K, V]) find(key K, hash uint32) (ln *linkedlist.LinkedList[payload[K, V]], index int, val V, ok bool) {
+	ln = m.chain(hash)
+
+	ln.ForEachWhile(func(i int, p payload[K, V]) bool {
+		if p.key == key {
+			index, val, ok = i, p.val, true
+			return false
+		}
+		return true
+	})
+
+	return ln, index, val, ok
+}
+
+// Upsert finds the entry relating to the passed key with a single hash and
+// walk of its bucket, then calls the passed function with a pointer to its
+// value and true, or with a pointer to the zero value and false if there is no
+// entry. The function returns whether the entry should be kept, so an existing
+// entry that isn't kept is removed and a missing entry that is kept is added.
+func (m *HashMap[K, V]) upsert(key K, f func(val *V, ok bool) (keep bool)) {
+	m.migrate(migrationStep)
+
+	h := hash.Hash(key)
+	ln := m.chain(h)
+
+	var found, removed bool
+	ln.Modify(func(p *payload[K, V]) (bool, bool) {
+		if p.key != key {
+			return false, false
+		}
+		found = true
+		removed = !f(&p.val, true)
+		return removed, true
+	})
+
+	if found {
+		if removed {
+			m.count--
+			m.shrink()
+		}
+		return
+	}
+
+	var val V
+	if !f(&val, false) {
+		return
+	}
+
 	if m.count+1 >= int(float64(m.capacity)*loadFactor) {
 		m.resize(m.capacity * 2)
+		ln = m.chain(h)
 	}
 
-	hash := hash.Hash(key)
-	bucket := int(math.Mod(float64(hash), float64(m.capacity)))
+	ln.InsertLast(payload[K, V]{key: key, val: val, hash: h})
+	m.count++
+}
 
-	var keyExists bool
-	m.data[bucket].ForEach(func(i int, p payload[K, V]) {
-		if p.key == key {
-			keyExists = true
-			m.data[bucket].Update(i, payload[K, V]{key: key, val: val})
-			return
+// Shrink reduces the amount of buckets to suit the amount of entries, if the
+// hash map has become sparse enough. However many entries have been removed
+// the hash map is only resized once.
+func (m *HashMap[K, V]) shrink() {
+	cap := m.capacity
+	for (cap/2) >= minBuckets && m.count < int((float64(cap)/2)*loadFactor) {
+		cap /= 2
+	}
+
+	if cap != m.capacity {
+		m.resize(cap)
+	}
+}
+
+// Put adds a value to the hash map relating to the passed key.
+func (m *HashMap[K, V]) Put(key K, val V) {
+	m.upsert(key, func(v *V, _ bool) bool {
+		*v = val
+		return true
+	})
+}
+
+// GetOrPut returns the value relating to the passed key and true if it's
+// present, otherwise it adds the passed value and returns it and false.
+func (m *HashMap[K, V]) GetOrPut(key K, val V) (actual V, loaded bool) {
+	m.upsert(key, func(v *V, ok bool) bool {
+		if !ok {
+			*v = val
+		}
+		actual, loaded = *v, ok
+		return true
+	})
+	return actual, loaded
+}
+
+// PutIfAbsent adds a value relating to the passed key only if the key isn't
+// already present, returning true if it was added.
+func (m *HashMap[K, V]) PutIfAbsent(key K, val V) bool {
+	_, loaded := m.GetOrPut(key, val)
+	return !loaded
+}
+
+// Compute calls the passed function with the value relating to the passed key
+// and true, or the zero value and false if the key isn't present. If the
+// function returns keep as true its value is put, otherwise the key is
+// removed. It returns the new value and true, or the zero value and false if
+// the key is no longer present.
+func (m *HashMap[K, V]) Compute(key K, f func(old V, ok bool) (val V, keep bool)) (val V, ok bool) {
+	m.upsert(key, func(v *V, present bool) bool {
+		val, ok = f(*v, present)
+		if ok {
+			*v = val
 		}
+		return ok
 	})
 
-	if !keyExists {
-		m.data[bucket].InsertLast(payload[K, V]{key: key, val: val})
-		m.count++
+	if !ok {
+		var zero V
+		return zero, false
 	}
+	return val, true
 }
 
-// Get gets a value from the hash map relating to the passed key.
-func (m HashMap[K, V]) Get(key K) (val V, ok bool) {
-	hash := hash.Hash(key)
-	bucket := int(math.Mod(float64(hash), float64(m.capacity)))
+// Merge puts the passed value relating to the passed key if the key isn't
+// present, otherwise it replaces the existing value with the result of calling
+// the passed function with it and the passed value. It returns the new value.
+func (m *HashMap[K, V]) Merge(key K, val V, f func(old V, val V) V) V {
+	var result V
+	m.upsert(key, func(v *V, ok bool) bool {
+		if ok {
+			*v = f(*v, val)
+		} else {
+			*v = val
+		}
+		result = *v
+		return true
+	})
+	return result
+}
 
-	m.data[bucket].ForEach(func(i int, p payload[K, V]) {
-		if p.key == key {
-			val = p.val
-			ok = true
-			return
+// Update replaces the value relating to the passed key with the result of
+// calling the passed function with it. It returns true if the key was present,
+// false if not, in which case nothing is added.
+func (m *HashMap[K, V]) Update(key K, f func(old V) V) bool {
+	var updated bool
+	m.upsert(key, func(v *V, ok bool) bool {
+		if ok {
+			*v = f(*v)
+			updated = true
 		}
+		return ok
 	})
+	return updated
+}
 
+// Swap puts a value relating to the passed key, returning the previous value
+// and true, or the zero value and false if the key wasn't present.
+func (m *HashMap[K, V]) Swap(key K, val V) (previous V, loaded bool) {
+	m.upsert(key, func(v *V, ok bool) bool {
+		previous, loaded = *v, ok
+		*v = val
+		return true
+	})
+	return previous, loaded
+}
+
+// CompareAndSwap replaces the value relating to the passed key with the passed
+// value, only if the key is present and its value is equal to old. It returns
+// true if the value was replaced.
+func (m *HashMap[K, V]) CompareAndSwap(key K, old V, val V) bool {
+	var swapped bool
+	m.upsert(key, func(v *V, ok bool) bool {
+		if ok && *v == old {
+			*v = val
+			swapped = true
+		}
+		return ok
+	})
+	return swapped
+}
+
+// PutAll adds every key and value from the passed hash map, overwriting any
+// existing values relating to the same keys.
+func (m *HashMap[K, V]) PutAll(other HashMap[K, V]) {
+	m.Grow(other.Count())
+	other.ForEach(func(key K, val V) {
+		m.Put(key, val)
+	})
+}
+
+// PutMap adds every key and value from the passed map, overwriting any existing
+// values relating to the same keys.
+func (m *HashMap[K, V]) PutMap(vals map[K]V) {
+	m.Grow(len(vals))
+	for k, v := range vals {
+		m.Put(k, v)
+	}
+}
+
+// Grow increases the capacity of the hash map, if necessary, so another n
+// entries can be added without triggering a resize.
+func (m *HashMap[K, V]) Grow(n int) {
+	cap := m.capacity
+	for m.count+n >= int(float64(cap)*loadFactor) {
+		cap *= 2
+	}
+
+	if cap != m.capacity {
+		m.resize(cap)
+	}
+}
+
+// Get gets a value from the hash map relating to the passed key.
+func (m HashMap[K, V]) Get(key K) (val V, ok bool) {
+	_, _, val, ok = m.find(key, hash.Hash(key))
+	return val, ok
+}
+
+// Remove deletes a value from the hash map relating to the passed key. It
+// returns the removed value and true, or the zero value and false if the key
+// wasn't present.
+func (m *HashMap[K, V]) Remove(key K) (val V, ok bool) {
+	m.upsert(key, func(v *V, present bool) bool {
+		val, ok = *v, present
+		return false
+	})
 	return val, ok
 }
 
-// Remove deletes a value from the hash map relating to the passed key.
-func (m *HashMap[K, V]) Remove(key K) {
-	hash := hash.Hash(key)
-	bucket := int(math.Mod(float64(hash), float64(m.capacity)))
+// Take removes and returns the value relating to the passed key.
+func (m *HashMap[K, V]) Take(key K) V {
+	val, ok := m.Remove(key)
+	if !ok {
+		panic("key not found, taking failed")
+	}
+	return val
+}
 
-	m.data[bucket].ForEach(func(i int, p payload[K, V]) {
-		if p.key == key {
-			m.data[bucket].Remove(i)
-			m.count--
-			return
+// Pop removes and returns an arbitrary entry from the hash map, or returns
+// false if the hash map is empty.
+func (m *HashMap[K, V]) Pop() (key K, val V, ok bool) {
+	m.forEachBucket(func(ln *linkedlist.LinkedList[payload[K, V]]) bool {
+		if ln.Empty() {
+			return true
 		}
+
+		p := ln.First()
+		ln.RemoveFirst()
+		key, val, ok = p.key, p.val, true
+		return false
 	})
 
-	if (m.capacity/2) >= minBuckets && m.count < int((float64(m.capacity)/2)*loadFactor) {
-		m.resize(m.capacity / 2)
+	if ok {
+		m.count--
+		m.shrink()
 	}
+
+	return key, val, ok
+}
+
+// RemoveIf removes every entry for which the passed predicate returns true,
+// returning the amount of entries removed. The hash map is resized at most once,
+// after every entry has been checked.
+func (m *HashMap[K, V]) RemoveIf(pred func(key K, val V) bool) int {
+	var removed int
+
+	m.forEachBucket(func(ln *linkedlist.LinkedList[payload[K, V]]) bool {
+		removed += ln.RemoveAll(func(p payload[K, V]) bool {
+			return pred(p.key, p.val)
+		})
+		return true
+	})
+
+	m.count -= removed
+	m.shrink()
+
+	return removed
+}
+
+// Retain removes every entry for which the passed predicate returns false,
+// returning the amount of entries removed. The hash map is resized at most
+// once, after every entry has been checked.
+func (m *HashMap[K, V]) Retain(pred func(key K, val V) bool) int {
+	return m.RemoveIf(func(key K, val V) bool {
+		return !pred(key, val)
+	})
 }
 
 // ContainsValue returns true if the passed value is present, false if not.
 func (m HashMap[K, V]) ContainsValue(val V) bool {
 	var result bool
-	for _, ln := range m.data {
-		ln.ForEach(func(i int, p payload[K, V]) {
-			if p.val == val {
-				result = true
-				return
-			}
+	m.forEachBucket(func(ln *linkedlist.LinkedList[payload[K, V]]) bool {
+		ln.ForEachWhile(func(i int, p payload[K, V]) bool {
+			result = p.val == val
+			return !result
 		})
-	}
+		return !result
+	})
 	return result
 }
 
 // ContainsKey returns true if the passed key is present, false if not.
 func (m HashMap[K, V]) ContainsKey(key K) bool {
-	var result bool
-	for _, ln := range m.data {
-		ln.ForEach(func(i int, p payload[K, V]) {
-			if p.key == key {
-				result = true
-				return
-			}
-		})
-	}
-	return result
+	_, _, _, ok := m.find(key, hash.Hash(key))
+	return ok
 }
 
 // Clear empties the entire hash map.
 func (m *HashMap[K, V]) Clear() {
+	if m.pool != nil {
+		for i := range m.old {
+			m.old[i].Clear()
+		}
+		for i := range m.data {
+			m.data[i].Clear()
+		}
+	}
+
 	m.capacity = minBuckets
-	m.data = make([]linkedlist.LinkedList[payload[K, V]], minBuckets)
+	m.data = m.buckets(minBuckets)
 	m.count = 0
+	m.old = nil
+	m.migrated = 0
+}
+
+// ForEachBucket calls the passed function for every bucket holding entries,
+// including the old buckets of an incremental resize, until it returns false.
+func (m HashMap[K, V]) forEachBucket(f func(ln *linkedlist.LinkedList[payload[K, V]]) bool) {
+	for i := m.migrated; i < len(m.old); i++ {
+		if !f(&m.old[i]) {
+			return
+		}
+	}
+
+	for i := range m.data {
+		if !f(&m.data[i]) {
+			return
+		}
+	}
 }
 
 // ForEach iterates over the dataset within the hash map, calling the passed
 // function for each value.
 func (m HashMap[K, V]) ForEach(f func(key K, val V)) {
-	for _, ln := range m.data {
+	m.forEachBucket(func(ln *linkedlist.LinkedList[payload[K, V]]) bool {
 		ln.ForEach(func(i int, p payload[K, V]) {
 			f(p.key, p.val)
 		})
+		return true
+	})
+}
+
+// ToMap returns a map containing the keys and values of the hash map.
+func (m HashMap[K, V]) ToMap() map[K]V {
+	vals := make(map[K]V, m.Count())
+	m.ForEach(func(key K, val V) {
+		vals[key] = val
+	})
+	return vals
+}
+
+// Clone returns a copy of the hash map that shares no buckets with the
+// original. The copy of a pooled map has its own pool, and the copy of a map
+// part way through an incremental resize is part way through the same resize.
+func (m HashMap[K, V]) Clone() HashMap[K, V] {
+	c := HashMap[K, V]{
+		capacity:    m.capacity,
+		count:       m.count,
+		incremental: m.incremental,
+		migrated:    m.migrated,
+	}
+
+	if m.pool != nil {
+		c.pool = linkedlist.NewPool[payload[K, V]](poolSlabSize)
+	}
+
+	c.data = c.cloneBuckets(m.data)
+	if m.old != nil {
+		c.old = c.cloneBuckets(m.old)
 	}
+
+	return c
+}
+
+// CloneBuckets returns a copy of the passed buckets, taking nodes from the
+// pool of this map if it has one.
+func (m HashMap[K, V]) cloneBuckets(data []linkedlist.LinkedList[payload[K, V]]) []linkedlist.LinkedList[payload[K, V]] {
+	c := m.buckets(len(data))
+
+	for i, ln := range data {
+		ln.ForEach(func(_ int, p payload[K, V]) {
+			c[i].InsertLast(p)
+		})
+	}
+
+	return c
+}
+
+// Equal returns true if both hash maps contain the same keys relating to the
+// same values, false if not.
+func (m HashMap[K, V]) Equal(other HashMap[K, V]) bool {
+	if m.Count() != other.Count() {
+		return false
+	}
+
+	result := true
+	m.ForEach(func(key K, val V) {
+		if v, ok := other.Get(key); !ok || v != val {
+			result = false
+		}
+	})
+
+	return result
 }
@@ -1,10 +1,13 @@
 package hashmap
 
 import (
+	"math"
+	"math/rand"
 	"strconv"
 	"testing"
 
 	"github.com/nomad-software/assert"
+	"github.com/nomad-software/goad/hash"
 )
 
 func TestNew(t *testing.T) {
@@ -80,6 +83,40 @@ func TestFailedResize(t *testing.T) {
 	m.resize(2)
 }
 
+func TestFailedResizePowerOfTwo(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	m := New[string, int]()
+	m.resize(48)
+}
+
+func TestBucketSpread(t *testing.T) {
+	t.Parallel()
+
+	// These hashes only differ in their high bits, so without mixing they
+	// would all land in the first bucket.
+	m := New[identity, int]()
+	for i := 0; i < 1_000; i++ {
+		m.Put(identity(i<<16), i)
+	}
+
+	var longest int
+	for _, ln := range m.data {
+		longest = max(longest, ln.Count())
+	}
+	assert.True(t, longest < 10)
+
+	for i := 0; i < 1_000; i++ {
+		assert.True(t, m.ContainsKey(identity(i<<16)))
+	}
+}
+
 func TestLargeCapacity(t *testing.T) {
 	t.Parallel()
 
@@ -127,6 +164,85 @@ func TestContains(t *testing.T) {
 	assert.False(t, m.ContainsValue(10))
 }
 
+// Colliding is a key type whose hashes all land in the same bucket.
+type colliding int
+
+func (c colliding) Hash() uint32 {
+	return 0
+}
+
+func TestCollisions(t *testing.T) {
+	t.Parallel()
+
+	m := New[colliding, int]()
+	for i := 0; i < 10; i++ {
+		m.Put(colliding(i), i)
+	}
+
+	assert.Eq(t, m.data[0].Count(), 10)
+
+	m.Put(colliding(5), 50)
+	assert.Eq(t, m.Count(), 10)
+	assert.Eq(t, m.data[0].Count(), 10)
+
+	val, ok := m.Get(colliding(5))
+	assert.True(t, ok)
+	assert.Eq(t, val, 50)
+
+	m.Remove(colliding(3))
+	assert.Eq(t, m.Count(), 9)
+	assert.False(t, m.ContainsKey(colliding(3)))
+
+	for _, i := range []int{0, 1, 2, 4, 6, 7, 8, 9} {
+		val, ok := m.Get(colliding(i))
+		assert.True(t, ok)
+		assert.Eq(t, val, i)
+	}
+
+	assert.True(t, m.ContainsValue(50))
+	assert.False(t, m.ContainsValue(5))
+}
+
+func TestComparisons(t *testing.T) {
+	t.Parallel()
+
+	m := New[int, int]()
+	limit := 10_000
+
+	for i := 0; i < limit; i++ {
+		m.Put(i, i)
+	}
+
+	// A successful lookup stops at the matching entry, so the comparisons made
+	// are its position in the bucket plus one.
+	var hits int
+	for i := 0; i < limit; i++ {
+		_, index, _, ok := m.find(i, hash.Hash(i))
+		assert.True(t, ok)
+		hits += index + 1
+	}
+	assert.True(t, hits < 2*limit)
+
+	// A failed lookup compares against every entry in one bucket only.
+	var misses int
+	for i := limit; i < 2*limit; i++ {
+		ln, _, _, ok := m.find(i, hash.Hash(i))
+		assert.False(t, ok)
+		misses += ln.Count()
+	}
+	assert.True(t, misses < 2*limit)
+
+	// Keys placed in the wrong bucket are never found, proving lookups don't
+	// scan the rest of the map.
+	h := hash.Hash(-1)
+	b := (bucket(h, m.capacity) + 1) % m.capacity
+	m.data[b].InsertLast(payload[int, int]{key: -1, val: -1, hash: h})
+
+	assert.False(t, m.ContainsKey(-1))
+	_, ok := m.Get(-1)
+	assert.False(t, ok)
+}
+
 func TestClearing(t *testing.T) {
 	t.Parallel()
 
@@ -179,6 +295,599 @@ func TestForEach(t *testing.T) {
 	})
 }
 
+func TestPutAll(t *testing.T) {
+	t.Parallel()
+
+	a := New[string, int]()
+	a.Put("a", 1)
+	a.Put("b", 2)
+
+	b := New[string, int]()
+	b.Put("b", 20)
+	b.Put("c", 30)
+
+	a.PutAll(b)
+	assert.Eq(t, a.Count(), 3)
+	assert.Eq(t, b.Count(), 2)
+
+	val, _ := a.Get("b")
+	assert.Eq(t, val, 20)
+
+	a.PutMap(map[string]int{"c": 3, "d": 4})
+	assert.Eq(t, a.Count(), 4)
+
+	val, _ = a.Get("c")
+	assert.Eq(t, val, 3)
+	val, _ = a.Get("d")
+	assert.Eq(t, val, 4)
+}
+
+func TestGrow(t *testing.T) {
+	t.Parallel()
+
+	m := New[int, int]()
+	m.Put(0, 0)
+	m.Grow(1_000)
+	capacity := m.capacity
+
+	assert.True(t, capacity > minBuckets)
+	assert.Eq(t, m.Count(), 1)
+
+	for i := 1; i <= 1_000; i++ {
+		m.Put(i, i)
+	}
+	assert.Eq(t, m.capacity, capacity)
+
+	m.Grow(0)
+	assert.Eq(t, m.capacity, capacity)
+
+	vals := make(map[int]int)
+	for i := 0; i < 10_000; i++ {
+		vals[i] = i
+	}
+
+	m.PutMap(vals)
+	assert.Eq(t, m.Count(), 10_000)
+	assert.True(t, m.count < int(float64(m.capacity)*loadFactor))
+}
+
+func TestMaps(t *testing.T) {
+	t.Parallel()
+
+	vals := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		vals[strconv.Itoa(i)] = i
+	}
+
+	m := FromMap(vals)
+	assert.Eq(t, m.Count(), 100)
+
+	val, ok := m.Get("42")
+	assert.True(t, ok)
+	assert.Eq(t, val, 42)
+
+	m.Put("100", 100)
+	exported := m.ToMap()
+	assert.Eq(t, len(exported), 101)
+	assert.Eq(t, len(vals), 100)
+
+	for k, v := range exported {
+		assert.Eq(t, k, strconv.Itoa(v))
+	}
+
+	assert.True(t, FromMap(exported).Equal(m))
+	assert.True(t, FromMap[string, int](nil).Empty())
+}
+
+func TestPooled(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+	m := NewPooled[int, int]()
+	expected := make(map[int]int)
+
+	for i := 0; i < 20_000; i++ {
+		key := r.Intn(2_000)
+		if r.Intn(3) == 0 {
+			m.Remove(key)
+			delete(expected, key)
+		} else {
+			m.Put(key, i)
+			expected[key] = i
+		}
+	}
+
+	assert.Eq(t, m.Count(), len(expected))
+	for k, v := range expected {
+		val, ok := m.Get(k)
+		assert.True(t, ok)
+		assert.Eq(t, val, v)
+	}
+
+	for k := range expected {
+		m.Remove(k)
+	}
+	assert.True(t, m.Empty())
+	assert.Eq(t, m.capacity, minBuckets)
+
+	// Every node is back in the pool, so refilling the map reuses them.
+	available := m.pool.Available()
+	for i := 0; i < 10; i++ {
+		m.Put(i, i)
+	}
+	assert.Eq(t, m.pool.Available(), available-10)
+
+	m.Clear()
+	assert.Eq(t, m.pool.Available(), available)
+}
+
+func TestPooledClone(t *testing.T) {
+	t.Parallel()
+
+	m := NewPooled[string, int]()
+	m.Put("foo", 1)
+	m.Put("bar", 2)
+
+	c := m.Clone()
+	assert.True(t, c.Equal(m))
+	assert.True(t, c.pool != m.pool)
+
+	c.Remove("foo")
+	c.Put("baz", 3)
+	m.Clear()
+
+	assert.True(t, m.Empty())
+	assert.Eq(t, c.Count(), 2)
+	assert.True(t, c.ContainsKey("bar"))
+	assert.True(t, c.ContainsKey("baz"))
+}
+
+func TestRehash(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 1_000; i++ {
+		m.Put(i, i)
+	}
+
+	// Only the new bucket array is allocated, every node is moved.
+	allocs := testing.AllocsPerRun(10, func() {
+		m.resize(m.capacity * 2)
+	})
+	assert.Eq(t, allocs, 1.0)
+
+	assert.Eq(t, m.Count(), 1_000)
+	for i := 0; i < 1_000; i++ {
+		val, ok := m.Get(i)
+		assert.True(t, ok)
+		assert.Eq(t, val, i)
+	}
+}
+
+func TestIncremental(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+	m := NewIncremental[int, int]()
+	expected := make(map[int]int)
+	var resizing int
+
+	for i := 0; i < 50_000; i++ {
+		key := r.Intn(5_000)
+		migrated := m.migrated
+
+		if i > 25_000 && r.Intn(3) != 0 {
+			m.Remove(key)
+			delete(expected, key)
+		} else {
+			m.Put(key, i)
+			expected[key] = i
+		}
+
+		if m.old != nil {
+			resizing++
+			assert.True(t, m.migrated-migrated <= migrationStep)
+		}
+
+		if i%1_000 == 0 {
+			assert.Eq(t, m.Count(), len(expected))
+			for k, v := range expected {
+				val, ok := m.Get(k)
+				assert.True(t, ok)
+				assert.Eq(t, val, v)
+			}
+		}
+	}
+
+	assert.True(t, resizing > 0)
+	assert.Eq(t, m.Count(), len(expected))
+	assert.True(t, m.Equal(FromMap(expected)))
+
+	var count int
+	m.ForEach(func(key int, val int) {
+		assert.Eq(t, expected[key], val)
+		count++
+	})
+	assert.Eq(t, count, len(expected))
+}
+
+func TestIncrementalResizing(t *testing.T) {
+	t.Parallel()
+
+	m := NewIncremental[int, int]()
+	for i := 0; i < 11; i++ {
+		m.Put(i, i)
+	}
+	assert.True(t, m.old == nil)
+
+	m.Put(11, 11)
+	assert.Eq(t, m.capacity, 32)
+	assert.True(t, m.old != nil)
+	assert.Eq(t, m.migrated, 0)
+
+	m.Put(11, 11)
+	assert.Eq(t, m.migrated, 8)
+
+	// Entries are found in whichever buckets they are in, and new entries
+	// belonging to old buckets go there until they are migrated.
+	for i := 0; i < 12; i++ {
+		assert.True(t, m.ContainsKey(i))
+	}
+	assert.True(t, m.ContainsValue(11))
+	assert.Eq(t, len(m.ToMap()), 12)
+
+	c := m.Clone()
+	m.Put(12, 12)
+	assert.True(t, m.old == nil)
+	assert.True(t, c.old != nil)
+	assert.Eq(t, c.Count(), 12)
+	assert.False(t, c.ContainsKey(12))
+
+	c.Put(13, 13)
+	assert.True(t, c.old == nil)
+	for i := 0; i < 12; i++ {
+		assert.True(t, c.ContainsKey(i))
+	}
+
+	m.Put(20, 20)
+	m.Put(21, 21)
+	m.Clear()
+	assert.True(t, m.Empty())
+	assert.True(t, m.old == nil)
+	assert.Eq(t, m.capacity, minBuckets)
+}
+
+func TestGetOrPut(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int]()
+
+	val, loaded := m.GetOrPut("foo", 1)
+	assert.Eq(t, val, 1)
+	assert.False(t, loaded)
+
+	val, loaded = m.GetOrPut("foo", 2)
+	assert.Eq(t, val, 1)
+	assert.True(t, loaded)
+
+	assert.True(t, m.PutIfAbsent("bar", 3))
+	assert.False(t, m.PutIfAbsent("bar", 4))
+
+	val, _ = m.Get("bar")
+	assert.Eq(t, val, 3)
+	assert.Eq(t, m.Count(), 2)
+}
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int]()
+
+	val, ok := m.Compute("foo", func(old int, ok bool) (int, bool) {
+		assert.False(t, ok)
+		return old + 1, true
+	})
+	assert.Eq(t, val, 1)
+	assert.True(t, ok)
+
+	val, ok = m.Compute("foo", func(old int, ok bool) (int, bool) {
+		assert.True(t, ok)
+		return old * 10, true
+	})
+	assert.Eq(t, val, 10)
+	assert.True(t, ok)
+
+	val, ok = m.Compute("foo", func(old int, ok bool) (int, bool) {
+		return 0, false
+	})
+	assert.Eq(t, val, 0)
+	assert.False(t, ok)
+	assert.False(t, m.ContainsKey("foo"))
+	assert.True(t, m.Empty())
+
+	_, ok = m.Compute("bar", func(old int, ok bool) (int, bool) {
+		return 5, false
+	})
+	assert.False(t, ok)
+	assert.True(t, m.Empty())
+}
+
+func TestComputeResizing(t *testing.T) {
+	t.Parallel()
+
+	m := New[int, int]()
+	for i := 0; i < 12; i++ {
+		m.Compute(i, func(old int, ok bool) (int, bool) {
+			return i, true
+		})
+	}
+	assert.Eq(t, m.Count(), 12)
+	assert.Eq(t, m.capacity, 32)
+
+	m.Compute(11, func(old int, ok bool) (int, bool) {
+		return 0, false
+	})
+	assert.Eq(t, m.Count(), 11)
+	assert.Eq(t, m.capacity, 16)
+
+	for i := 0; i < 11; i++ {
+		val, ok := m.Get(i)
+		assert.True(t, ok)
+		assert.Eq(t, val, i)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int]()
+	sum := func(old int, val int) int { return old + val }
+
+	for _, word := range []string{"a", "b", "a", "c", "a", "b"} {
+		m.Merge(word, 1, sum)
+	}
+
+	assert.Eq(t, m.Merge("a", 10, sum), 13)
+	assert.True(t, m.Equal(FromMap(map[string]int{"a": 13, "b": 2, "c": 1})))
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int]()
+	m.Put("foo", 1)
+
+	assert.True(t, m.Update("foo", func(old int) int { return old + 1 }))
+	assert.False(t, m.Update("bar", func(old int) int { return old + 1 }))
+
+	val, _ := m.Get("foo")
+	assert.Eq(t, val, 2)
+	assert.False(t, m.ContainsKey("bar"))
+	assert.Eq(t, m.Count(), 1)
+}
+
+func TestSwap(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int]()
+
+	old, loaded := m.Swap("foo", 1)
+	assert.Eq(t, old, 0)
+	assert.False(t, loaded)
+
+	old, loaded = m.Swap("foo", 2)
+	assert.Eq(t, old, 1)
+	assert.True(t, loaded)
+
+	assert.False(t, m.CompareAndSwap("foo", 1, 3))
+	assert.True(t, m.CompareAndSwap("foo", 2, 3))
+	assert.False(t, m.CompareAndSwap("bar", 0, 3))
+
+	val, _ := m.Get("foo")
+	assert.Eq(t, val, 3)
+	assert.False(t, m.ContainsKey("bar"))
+}
+
+// Counted is a key type that counts how many times it's hashed.
+type counted struct {
+	id    int
+	calls *int
+}
+
+func (c counted) Hash() uint32 {
+	*c.calls++
+	return uint32(c.id)
+}
+
+func TestSingleHash(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	m := New[counted, int]()
+	key := counted{id: 1, calls: &calls}
+	sum := func(old int, val int) int { return old + val }
+
+	ops := []func(){
+		func() { m.Put(key, 1) },
+		func() { m.GetOrPut(key, 2) },
+		func() { m.PutIfAbsent(key, 2) },
+		func() { m.Merge(key, 2, sum) },
+		func() { m.Update(key, func(old int) int { return old * 2 }) },
+		func() { m.Swap(key, 3) },
+		func() { m.CompareAndSwap(key, 3, 4) },
+		func() {
+			m.Compute(key, func(old int, ok bool) (int, bool) { return old, true })
+		},
+	}
+
+	for _, op := range ops {
+		calls = 0
+		op()
+		assert.Eq(t, calls, 1)
+	}
+
+	val, _ := m.Get(key)
+	assert.Eq(t, val, 4)
+}
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int]()
+	m.Put("foo", 1)
+	m.Put("bar", 2)
+
+	val, ok := m.Remove("foo")
+	assert.True(t, ok)
+	assert.Eq(t, val, 1)
+
+	val, ok = m.Remove("foo")
+	assert.False(t, ok)
+	assert.Eq(t, val, 0)
+
+	assert.Eq(t, m.Take("bar"), 2)
+	assert.True(t, m.Empty())
+}
+
+func TestFailedTake(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	m := New[string, int]()
+	m.Take("foo")
+}
+
+func TestPop(t *testing.T) {
+	t.Parallel()
+
+	m := New[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, i*10)
+	}
+
+	seen := make(map[int]bool)
+	for !m.Empty() {
+		key, val, ok := m.Pop()
+		assert.True(t, ok)
+		assert.Eq(t, val, key*10)
+		assert.False(t, seen[key])
+		seen[key] = true
+	}
+
+	assert.Eq(t, len(seen), 100)
+	assert.Eq(t, m.capacity, minBuckets)
+
+	_, _, ok := m.Pop()
+	assert.False(t, ok)
+}
+
+func TestRemoveIf(t *testing.T) {
+	t.Parallel()
+
+	m := New[int, int]()
+	for i := 0; i < 10_000; i++ {
+		m.Put(i, i)
+	}
+	assert.Eq(t, m.capacity, 16_384)
+
+	removed := m.RemoveIf(func(key int, val int) bool {
+		return key%10 != 0
+	})
+	assert.Eq(t, removed, 9_000)
+	assert.Eq(t, m.Count(), 1_000)
+	assert.Eq(t, m.capacity, 2_048)
+
+	removed = m.Retain(func(key int, val int) bool {
+		return key < 500
+	})
+	assert.Eq(t, removed, 950)
+	assert.Eq(t, m.Count(), 50)
+	assert.Eq(t, m.capacity, 128)
+
+	for i := 0; i < 1_000; i++ {
+		assert.Eq(t, m.ContainsKey(i), i < 500 && i%10 == 0)
+	}
+}
+
+func TestRemoveIfIncremental(t *testing.T) {
+	t.Parallel()
+
+	m := NewIncremental[int, int]()
+	for i := 0; i < 1_000; i++ {
+		m.Put(i, i)
+	}
+	for m.old == nil {
+		m.Put(m.Count(), 0)
+	}
+
+	count := m.Count()
+	assert.Eq(t, m.RemoveIf(func(key int, val int) bool { return key%2 == 0 }), (count+1)/2)
+	assert.Eq(t, m.Count(), count/2)
+
+	var seen int
+	m.ForEach(func(key int, val int) {
+		assert.Eq(t, key%2, 1)
+		seen++
+	})
+	assert.Eq(t, seen, count/2)
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	c := m.Clone()
+	assert.True(t, c.Equal(m))
+
+	c.Remove("a")
+	c.Put("b", 20)
+	m.Put("d", 4)
+
+	assert.False(t, c.Equal(m))
+	assert.Eq(t, m.Count(), 4)
+	assert.Eq(t, c.Count(), 2)
+
+	val, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Eq(t, val, 1)
+
+	val, _ = m.Get("b")
+	assert.Eq(t, val, 2)
+
+	val, _ = c.Get("b")
+	assert.Eq(t, val, 20)
+
+	_, ok = c.Get("d")
+	assert.False(t, ok)
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := New[string, int]()
+	b := New[string, int]()
+	assert.True(t, a.Equal(b))
+
+	a.Put("foo", 1)
+	a.Put("bar", 2)
+	b.Put("bar", 2)
+	b.Put("foo", 3)
+	assert.False(t, a.Equal(b))
+
+	b.Put("foo", 1)
+	assert.True(t, a.Equal(b))
+
+	b.Put("baz", 1)
+	assert.False(t, a.Equal(b))
+}
+
 func BenchmarkHashMapPut(b *testing.B) {
 	m := New[string, int]()
 
@@ -205,6 +914,65 @@ func BenchmarkHashMapGet(b *testing.B) {
 	}
 }
 
+// BucketMod is the previous way of selecting a bucket, kept to compare against.
+func bucketMod(hash uint32, buckets int) int {
+	return int(math.Mod(float64(hash), float64(buckets)))
+}
+
+func BenchmarkBucketMod(b *testing.B) {
+	var sum int
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		sum += bucketMod(uint32(x)*0x9e3779b9, 1<<20)
+	}
+	_ = sum
+}
+
+func BenchmarkBucket(b *testing.B) {
+	var sum int
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		sum += bucket(uint32(x)*0x9e3779b9, 1<<20)
+	}
+	_ = sum
+}
+
+func BenchmarkHashMapGetHasher(b *testing.B) {
+	m := New[identity, int]()
+
+	for x := 0; x < 1_000_000; x++ {
+		m.Put(identity(x), x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Get(identity(x % 1_000_000))
+	}
+}
+
+func BenchmarkHashMapContainsKey(b *testing.B) {
+	m := New[int, int]()
+
+	for x := 0; x < 1_000_000; x++ {
+		m.Put(x, x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.ContainsKey(x % 1_000_000)
+	}
+}
+
 func BenchmarkHashMapForEach(b *testing.B) {
 	m := New[int, int]()
 
@@ -219,3 +987,146 @@ func BenchmarkHashMapForEach(b *testing.B) {
 		m.ForEach(func(key int, val int) {})
 	}
 }
+
+// Identity is a key that hashes without allocating, so benchmarks using it
+// only count the allocations made by the map itself.
+type identity int
+
+func (i identity) Hash() uint32 {
+	return uint32(i)
+}
+
+func BenchmarkHashMapChurn(b *testing.B) {
+	m := New[identity, int]()
+	for x := 0; x < 1_000; x++ {
+		m.Put(identity(x), x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Remove(identity(x % 1_000))
+		m.Put(identity(x%1_000), x)
+	}
+}
+
+func BenchmarkPooledHashMapChurn(b *testing.B) {
+	m := NewPooled[identity, int]()
+	for x := 0; x < 1_000; x++ {
+		m.Put(identity(x), x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Remove(identity(x % 1_000))
+		m.Put(identity(x%1_000), x)
+	}
+}
+
+func BenchmarkHashMapFillAndClear(b *testing.B) {
+	m := New[identity, int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		for i := 0; i < 1_000; i++ {
+			m.Put(identity(i), i)
+		}
+		m.Clear()
+	}
+}
+
+func BenchmarkPooledHashMapFillAndClear(b *testing.B) {
+	m := NewPooled[identity, int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		for i := 0; i < 1_000; i++ {
+			m.Put(identity(i), i)
+		}
+		m.Clear()
+	}
+}
+
+func BenchmarkHashMapResize(b *testing.B) {
+	m := New[int, int]()
+	for x := 0; x < 100_000; x++ {
+		m.Put(x, x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.resize(m.capacity * 2)
+		m.resize(m.capacity / 2)
+	}
+}
+
+func BenchmarkHashMapFill(b *testing.B) {
+	b.ReportAllocs()
+
+	for x := 0; x < b.N; x++ {
+		m := New[identity, int]()
+		for i := 0; i < 100_000; i++ {
+			m.Put(identity(i), i)
+		}
+	}
+}
+
+func BenchmarkIncrementalHashMapFill(b *testing.B) {
+	b.ReportAllocs()
+
+	for x := 0; x < b.N; x++ {
+		m := NewIncremental[identity, int]()
+		for i := 0; i < 100_000; i++ {
+			m.Put(identity(i), i)
+		}
+	}
+}
+
+func BenchmarkHashMapGetAndPut(b *testing.B) {
+	m := New[identity, int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		key := identity(x % 1_000)
+		val, _ := m.Get(key)
+		m.Put(key, val+1)
+	}
+}
+
+func BenchmarkHashMapMerge(b *testing.B) {
+	m := New[identity, int]()
+	sum := func(old int, val int) int { return old + val }
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Merge(identity(x%1_000), 1, sum)
+	}
+}
+
+func BenchmarkHashMapRemoveIf(b *testing.B) {
+	b.ReportAllocs()
+
+	for x := 0; x < b.N; x++ {
+		b.StopTimer()
+		m := New[identity, int]()
+		for i := 0; i < 100_000; i++ {
+			m.Put(identity(i), i)
+		}
+		b.StartTimer()
+
+		m.RemoveIf(func(key identity, val int) bool { return val%100 != 0 })
+	}
+}
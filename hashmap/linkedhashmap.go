@@ -0,0 +1,403 @@
+package hashmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/nomad-software/goad/intrusivelist"
+)
+
+// Entry is an entry of a linked hash map, linked to the entries either side of
+// it in order.
+type entry[K comparable, V comparable] struct {
+	intrusivelist.Link[entry[K, V]]
+	key K
+	val V
+}
+
+// LinkedHashMap is a hash map that remembers the order of its entries.
+// By default entries are kept in the order they were first put, but a linked
+// hash map can also keep them in the order they were last accessed. Every
+// operation besides iteration takes constant time, as with a hash map. A copy
+// made by assignment shares its entries and their order, but keeps its own
+// ends of the order, so removing through one copy can leave the other
+// iterating over removed entries. Use Clone for an independent map.
+type LinkedHashMap[K comparable, V comparable] struct {
+	data     HashMap[K, *entry[K, V]]
+	order    intrusivelist.List[entry[K, V], *entry[K, V]]
+	byAccess bool
+}
+
+// NewLinked is used to create a new linked hash map that keeps its entries in
+// the order they were first put. Putting a key again updates its value without
+// moving it.
+func NewLinked[K comparable, V comparable]() LinkedHashMap[K, V] {
+	return LinkedHashMap[K, V]{
+		data:  New[K, *entry[K, V]](),
+		order: intrusivelist.New[entry[K, V]](),
+	}
+}
+
+// NewLinkedByAccess is used to create a new linked hash map that keeps its
+// entries in the order they were last put or got, least recent first. This
+// makes it suitable for least recently used caches, evicting with RemoveFirst.
+func NewLinkedByAccess[K comparable, V comparable]() LinkedHashMap[K, V] {
+	m := NewLinked[K, V]()
+	m.byAccess = true
+	return m
+}
+
+// Count returns the amount of entries in the map.
+func (m LinkedHashMap[K, V]) Count() int {
+	return m.data.Count()
+}
+
+// Empty returns true if the map is empty, false if not.
+func (m LinkedHashMap[K, V]) Empty() bool {
+	return m.Count() == 0
+}
+
+// ByAccess returns true if the entries are kept in the order they were last
+// accessed, false if in the order they were first put.
+func (m LinkedHashMap[K, V]) ByAccess() bool {
+	return m.byAccess
+}
+
+// Touch moves the passed entry to the end if the entries are kept in access
+// order.
+func (m *LinkedHashMap[K, V]) touch(e *entry[K, V]) {
+	if m.byAccess {
+		m.order.Remove(e)
+		m.order.InsertLast(e)
+	}
+}
+
+// Put adds a value to the map relating to the passed key. A new key is added
+// at the end.
+func (m *LinkedHashMap[K, V]) Put(key K, val V) {
+	m.data.upsert(key, func(e **entry[K, V], ok bool) bool {
+		if ok {
+			(*e).val = val
+			m.touch(*e)
+		} else {
+			*e = &entry[K, V]{key: key, val: val}
+			m.order.InsertLast(*e)
+		}
+		return true
+	})
+}
+
+// Get gets a value from the map relating to the passed key. If the entries
+// are kept in access order this moves the entry to the end.
+func (m *LinkedHashMap[K, V]) Get(key K) (val V, ok bool) {
+	e, ok := m.data.Get(key)
+	if !ok {
+		return val, false
+	}
+
+	m.touch(e)
+	return e.val, true
+}
+
+// Remove deletes a value from the map relating to the passed key. It returns
+// the removed value and true, or the zero value and false if the key wasn't
+// present.
+func (m *LinkedHashMap[K, V]) Remove(key K) (val V, ok bool) {
+	e, ok := m.data.Remove(key)
+	if !ok {
+		return val, false
+	}
+
+	m.order.Remove(e)
+	return e.val, true
+}
+
+// First returns the key and value of the first entry and true, or false if
+// the map is empty.
+func (m LinkedHashMap[K, V]) First() (key K, val V, ok bool) {
+	if e := m.order.First(); e != nil {
+		return e.key, e.val, true
+	}
+	return key, val, false
+}
+
+// Last returns the key and value of the last entry and true, or false if the
+// map is empty.
+func (m LinkedHashMap[K, V]) Last() (key K, val V, ok bool) {
+	if e := m.order.Last(); e != nil {
+		return e.key, e.val, true
+	}
+	return key, val, false
+}
+
+// RemoveFirst removes and returns the key and value of the first entry, or
+// returns false if the map is empty.
+func (m *LinkedHashMap[K, V]) RemoveFirst() (key K, val V, ok bool) {
+	e := m.order.First()
+	if e == nil {
+		return key, val, false
+	}
+
+	m.data.Remove(e.key)
+	m.order.Remove(e)
+	return e.key, e.val, true
+}
+
+// MoveToEnd moves the entry relating to the passed key to the end, returning
+// true if the key was present or false if not.
+func (m *LinkedHashMap[K, V]) MoveToEnd(key K) bool {
+	e, ok := m.data.Get(key)
+	if ok {
+		m.order.Remove(e)
+		m.order.InsertLast(e)
+	}
+	return ok
+}
+
+// MoveToFront moves the entry relating to the passed key to the front,
+// returning true if the key was present or false if not.
+func (m *LinkedHashMap[K, V]) MoveToFront(key K) bool {
+	e, ok := m.data.Get(key)
+	if ok {
+		m.order.Remove(e)
+		m.order.InsertFirst(e)
+	}
+	return ok
+}
+
+// ContainsKey returns true if the passed key is present, false if not. This
+// never changes the order of the entries.
+func (m LinkedHashMap[K, V]) ContainsKey(key K) bool {
+	return m.data.ContainsKey(key)
+}
+
+// ContainsValue returns true if the passed value is present, false if not.
+func (m LinkedHashMap[K, V]) ContainsValue(val V) bool {
+	for e := m.order.First(); e != nil; e = m.order.Next(e) {
+		if e.val == val {
+			return true
+		}
+	}
+	return false
+}
+
+// Clear empties the entire map.
+func (m *LinkedHashMap[K, V]) Clear() {
+	m.data.Clear()
+	m.order.Clear()
+}
+
+// ForEach iterates over the dataset within the map in order, calling the
+// passed function for each value. This never changes the order of the entries.
+func (m LinkedHashMap[K, V]) ForEach(f func(key K, val V)) {
+	for e := m.order.First(); e != nil; e = m.order.Next(e) {
+		f(e.key, e.val)
+	}
+}
+
+// Keys returns the keys of the map in order.
+func (m LinkedHashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Count())
+	m.ForEach(func(key K, val V) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+// Values returns the values of the map in order.
+func (m LinkedHashMap[K, V]) Values() []V {
+	vals := make([]V, 0, m.Count())
+	m.ForEach(func(key K, val V) {
+		vals = append(vals, val)
+	})
+	return vals
+}
+
+// ToMap returns a map containing the keys and values of the map.
+func (m LinkedHashMap[K, V]) ToMap() map[K]V {
+	vals := make(map[K]V, m.Count())
+	m.ForEach(func(key K, val V) {
+		vals[key] = val
+	})
+	return vals
+}
+
+// Clone returns a copy of the map that shares no entries with the original.
+func (m LinkedHashMap[K, V]) Clone() LinkedHashMap[K, V] {
+	c := NewLinked[K, V]()
+	c.byAccess = m.byAccess
+	c.data.Grow(m.Count())
+
+	m.ForEach(func(key K, val V) {
+		c.Put(key, val)
+	})
+
+	return c
+}
+
+// Equal returns true if both maps contain the same keys relating to the same
+// values in the same order, false if not.
+func (m LinkedHashMap[K, V]) Equal(other LinkedHashMap[K, V]) bool {
+	if m.Count() != other.Count() {
+		return false
+	}
+
+	a, b := m.order.First(), other.order.First()
+	for ; a != nil; a, b = m.order.Next(a), other.order.Next(b) {
+		if a.key != b.key || a.val != b.val {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MarshalJSON encodes the map as a JSON object with its keys in order. Keys
+// are encoded in the same way encoding/json encodes the keys of a map, so
+// they must be strings, integers or implement encoding.TextMarshaler.
+func (m LinkedHashMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for e := m.order.First(); e != nil; e = m.order.Next(e) {
+		if e != m.order.First() {
+			buf.WriteByte(',')
+		}
+
+		key, err := marshalKey(e.key)
+		if err != nil {
+			return nil, err
+		}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := json.Marshal(e.val)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into the map, putting its keys in the
+// order they appear. Keys already in the map are updated, as with Put. A zero
+// value map can be decoded into, in which case it keeps entries in the order
+// they were first put.
+func (m *LinkedHashMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok == nil {
+		return nil
+	}
+
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return errors.New("json value is not an object")
+	}
+
+	if m.data.data == nil {
+		byAccess := m.byAccess
+		*m = NewLinked[K, V]()
+		m.byAccess = byAccess
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		key, err := unmarshalKey[K](tok.(string))
+		if err != nil {
+			return err
+		}
+
+		var val V
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+
+		m.Put(key, val)
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// MarshalKey returns the passed key as a JSON object key.
+func marshalKey(key any) (string, error) {
+	v := reflect.ValueOf(key)
+
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+
+	if m, ok := key.(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+
+	return "", fmt.Errorf("unsupported json key type %T", key)
+}
+
+// UnmarshalKey returns the passed JSON object key as a key of the map. As with
+// encoding/json, encoding.TextUnmarshaler takes precedence over the kind of the
+// key, even for string kinds.
+func unmarshalKey[K comparable](s string) (key K, err error) {
+	if u, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err = u.UnmarshalText([]byte(s))
+		return key, err
+	}
+
+	v := reflect.ValueOf(&key).Elem()
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return key, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("invalid json key %q for type %T", s, key)
+		}
+		v.SetInt(n)
+		return key, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("invalid json key %q for type %T", s, key)
+		}
+		v.SetUint(n)
+		return key, nil
+	}
+
+	return key, fmt.Errorf("unsupported json key type %T", key)
+}
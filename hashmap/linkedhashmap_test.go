@@ -0,0 +1,457 @@
+package hashmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+func TestNewLinked(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinked[string, int]()
+	assert.True(t, m.Empty())
+	assert.False(t, m.ByAccess())
+
+	m.Put("c", 1)
+	m.Put("a", 2)
+	m.Put("b", 3)
+	m.Put("a", 4)
+
+	assert.Eq(t, m.Count(), 3)
+	assert.True(t, slices.Equal(m.Keys(), []string{"c", "a", "b"}))
+	assert.True(t, slices.Equal(m.Values(), []int{1, 4, 3}))
+
+	val, ok := m.Get("c")
+	assert.True(t, ok)
+	assert.Eq(t, val, 1)
+	assert.True(t, slices.Equal(m.Keys(), []string{"c", "a", "b"}))
+
+	_, ok = m.Get("d")
+	assert.False(t, ok)
+}
+
+func TestLinkedOrderSurvivesResizing(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinked[int, int]()
+	expected := make([]int, 0)
+
+	for i := 0; i < 10_000; i++ {
+		key := (i * 7919) % 10_007
+		m.Put(key, i)
+		expected = append(expected, key)
+	}
+
+	remaining := make([]int, 0)
+	for i, key := range expected {
+		if i%3 == 0 {
+			m.Remove(key)
+		} else {
+			remaining = append(remaining, key)
+		}
+	}
+	expected = remaining
+
+	assert.Eq(t, m.Count(), len(expected))
+	assert.True(t, slices.Equal(m.Keys(), expected))
+}
+
+func TestLinkedRemove(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinked[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	val, ok := m.Remove("b")
+	assert.True(t, ok)
+	assert.Eq(t, val, 2)
+
+	_, ok = m.Remove("b")
+	assert.False(t, ok)
+	assert.True(t, slices.Equal(m.Keys(), []string{"a", "c"}))
+
+	m.Put("b", 4)
+	assert.True(t, slices.Equal(m.Keys(), []string{"a", "c", "b"}))
+}
+
+func TestLinkedFirstAndLast(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinked[string, int]()
+
+	_, _, ok := m.First()
+	assert.False(t, ok)
+	_, _, ok = m.Last()
+	assert.False(t, ok)
+	_, _, ok = m.RemoveFirst()
+	assert.False(t, ok)
+
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	key, val, ok := m.First()
+	assert.True(t, ok)
+	assert.Eq(t, key, "a")
+	assert.Eq(t, val, 1)
+
+	key, val, ok = m.Last()
+	assert.True(t, ok)
+	assert.Eq(t, key, "c")
+	assert.Eq(t, val, 3)
+
+	key, val, ok = m.RemoveFirst()
+	assert.True(t, ok)
+	assert.Eq(t, key, "a")
+	assert.Eq(t, val, 1)
+	assert.False(t, m.ContainsKey("a"))
+	assert.Eq(t, m.Count(), 2)
+}
+
+func TestLinkedMove(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinked[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	assert.True(t, m.MoveToEnd("a"))
+	assert.True(t, slices.Equal(m.Keys(), []string{"b", "c", "a"}))
+
+	assert.True(t, m.MoveToFront("c"))
+	assert.True(t, slices.Equal(m.Keys(), []string{"c", "b", "a"}))
+
+	assert.False(t, m.MoveToEnd("d"))
+	assert.False(t, m.MoveToFront("d"))
+	assert.True(t, slices.Equal(m.Keys(), []string{"c", "b", "a"}))
+}
+
+func TestLinkedByAccess(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinkedByAccess[string, int]()
+	assert.True(t, m.ByAccess())
+
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	m.Get("a")
+	assert.True(t, slices.Equal(m.Keys(), []string{"b", "c", "a"}))
+
+	m.Put("b", 4)
+	assert.True(t, slices.Equal(m.Keys(), []string{"c", "a", "b"}))
+
+	// Checking for a key or iterating doesn't count as an access.
+	m.ContainsKey("c")
+	m.ForEach(func(key string, val int) {})
+	assert.True(t, slices.Equal(m.Keys(), []string{"c", "a", "b"}))
+}
+
+func TestLinkedLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	capacity := 3
+	cache := NewLinkedByAccess[int, string]()
+
+	for _, key := range []int{1, 2, 3, 1, 4, 2, 5} {
+		if _, ok := cache.Get(key); ok {
+			continue
+		}
+		if cache.Count() == capacity {
+			cache.RemoveFirst()
+		}
+		cache.Put(key, strconv.Itoa(key))
+	}
+
+	assert.True(t, slices.Equal(cache.Keys(), []int{4, 2, 5}))
+}
+
+func TestLinkedContains(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinked[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	assert.True(t, m.ContainsKey("a"))
+	assert.False(t, m.ContainsKey("c"))
+	assert.True(t, m.ContainsValue(2))
+	assert.False(t, m.ContainsValue(3))
+}
+
+func TestLinkedClearing(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinked[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	m.Clear()
+	assert.True(t, m.Empty())
+	assert.Eq(t, len(m.Keys()), 0)
+
+	m.Put("b", 3)
+	m.Put("a", 4)
+	assert.True(t, slices.Equal(m.Keys(), []string{"b", "a"}))
+	assert.True(t, slices.Equal(m.Values(), []int{3, 4}))
+}
+
+func TestLinkedForEach(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinked[int, int]()
+	for i := 100; i > 0; i-- {
+		m.Put(i, i*2)
+	}
+
+	next := 100
+	m.ForEach(func(key int, val int) {
+		assert.Eq(t, key, next)
+		assert.Eq(t, val, next*2)
+		next--
+	})
+	assert.Eq(t, next, 0)
+	assert.Eq(t, len(m.ToMap()), 100)
+}
+
+func TestLinkedClone(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinkedByAccess[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	c := m.Clone()
+	assert.True(t, c.Equal(m))
+	assert.True(t, c.ByAccess())
+
+	c.Get("a")
+	c.Put("c", 3)
+	m.Remove("b")
+
+	assert.True(t, slices.Equal(m.Keys(), []string{"a"}))
+	assert.True(t, slices.Equal(c.Keys(), []string{"b", "a", "c"}))
+}
+
+func TestLinkedEqual(t *testing.T) {
+	t.Parallel()
+
+	a := NewLinked[string, int]()
+	a.Put("a", 1)
+	a.Put("b", 2)
+
+	b := NewLinked[string, int]()
+	b.Put("b", 2)
+	b.Put("a", 1)
+
+	assert.False(t, a.Equal(b))
+
+	b.MoveToEnd("b")
+	assert.True(t, a.Equal(b))
+
+	b.Put("b", 3)
+	assert.False(t, a.Equal(b))
+}
+
+type point struct {
+	X int
+	Y int
+}
+
+func (p point) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d:%d", p.X, p.Y)), nil
+}
+
+func (p *point) UnmarshalText(b []byte) error {
+	_, err := fmt.Sscanf(string(b), "%d:%d", &p.X, &p.Y)
+	return err
+}
+
+// Lower is a string key type that is lower cased when it is unmarshalled.
+type lower string
+
+func (l *lower) UnmarshalText(b []byte) error {
+	*l = lower(strings.ToLower(string(b)))
+	return nil
+}
+
+func TestLinkedMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinked[string, [2]int]()
+	assert.Eq(t, mustMarshal(t, m), `{}`)
+
+	m.Put("zulu", [2]int{1})
+	m.Put("alpha", [2]int{})
+	m.Put("mike", [2]int{2, 3})
+	assert.Eq(t, mustMarshal(t, m), `{"zulu":[1,0],"alpha":[0,0],"mike":[2,3]}`)
+	assert.Eq(t, mustMarshal(t, &m), `{"zulu":[1,0],"alpha":[0,0],"mike":[2,3]}`)
+
+	n := NewLinked[int8, string]()
+	n.Put(3, "three")
+	n.Put(-1, "minus one")
+	assert.Eq(t, mustMarshal(t, n), `{"3":"three","-1":"minus one"}`)
+
+	p := NewLinked[point, bool]()
+	p.Put(point{2, 1}, true)
+	p.Put(point{1, 2}, false)
+	assert.Eq(t, mustMarshal(t, p), `{"2:1":true,"1:2":false}`)
+
+	type config struct {
+		Name    string
+		Options LinkedHashMap[string, int]
+	}
+
+	c := config{Name: "test", Options: NewLinked[string, int]()}
+	c.Options.Put("b", 1)
+	c.Options.Put("a", 2)
+	assert.Eq(t, mustMarshal(t, c), `{"Name":"test","Options":{"b":1,"a":2}}`)
+}
+
+func TestFailedMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinked[float64, int]()
+	m.Put(1.5, 1)
+
+	_, err := json.Marshal(m)
+	assert.True(t, err != nil)
+}
+
+func TestLinkedUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var m LinkedHashMap[string, int]
+	err := json.Unmarshal([]byte(`{"zulu": 1, "alpha": 2, "mike": 3, "alpha": 4}`), &m)
+	assert.True(t, err == nil)
+	assert.True(t, slices.Equal(m.Keys(), []string{"zulu", "alpha", "mike"}))
+	assert.True(t, slices.Equal(m.Values(), []int{1, 4, 3}))
+
+	err = json.Unmarshal([]byte(`{"bravo": 5, "zulu": 6}`), &m)
+	assert.True(t, err == nil)
+	assert.True(t, slices.Equal(m.Keys(), []string{"zulu", "alpha", "mike", "bravo"}))
+	assert.True(t, slices.Equal(m.Values(), []int{6, 4, 3, 5}))
+
+	err = json.Unmarshal([]byte(`null`), &m)
+	assert.True(t, err == nil)
+	assert.Eq(t, m.Count(), 4)
+
+	type size struct {
+		W int
+		H int
+	}
+
+	var n LinkedHashMap[uint16, size]
+	err = json.Unmarshal([]byte(`{"20": {"W": 1, "H": 2}, "10": {"W": 3}}`), &n)
+	assert.True(t, err == nil)
+	assert.True(t, slices.Equal(n.Keys(), []uint16{20, 10}))
+	assert.True(t, slices.Equal(n.Values(), []size{{1, 2}, {3, 0}}))
+
+	var p LinkedHashMap[point, string]
+	err = json.Unmarshal([]byte(`{"2:1": "a", "1:2": "b"}`), &p)
+	assert.True(t, err == nil)
+	assert.True(t, slices.Equal(p.Keys(), []point{{2, 1}, {1, 2}}))
+}
+
+func TestLinkedUnmarshalJSONTextKey(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"Foo": 1, "BAR": 2}`)
+
+	var want map[lower]int
+	err := json.Unmarshal(data, &want)
+	assert.True(t, err == nil)
+
+	// Keys implementing encoding.TextUnmarshaler decode as they would in a
+	// map, even when they are strings.
+	var m LinkedHashMap[lower, int]
+	err = json.Unmarshal(data, &m)
+	assert.True(t, err == nil)
+	assert.True(t, slices.Equal(m.Keys(), []lower{"foo", "bar"}))
+	assert.True(t, maps.Equal(m.ToMap(), want))
+}
+
+func TestLinkedJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	m := NewLinked[string, string]()
+	for i := 50; i > 0; i-- {
+		m.Put(strconv.Itoa(i), strings.Repeat("x", i))
+	}
+
+	var c LinkedHashMap[string, string]
+	err := json.Unmarshal([]byte(mustMarshal(t, m)), &c)
+	assert.True(t, err == nil)
+	assert.True(t, c.Equal(m))
+}
+
+func TestFailedUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var m LinkedHashMap[int, int]
+	assert.True(t, json.Unmarshal([]byte(`[1, 2]`), &m) != nil)
+	assert.True(t, json.Unmarshal([]byte(`{"a": 1}`), &m) != nil)
+	assert.True(t, json.Unmarshal([]byte(`{"300": 1}`), &LinkedHashMap[int8, int]{}) != nil)
+	assert.True(t, json.Unmarshal([]byte(`{"1": "a"}`), &m) != nil)
+	assert.True(t, json.Unmarshal([]byte(`{"1": 1.5}`), &LinkedHashMap[float64, int]{}) != nil)
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func BenchmarkLinkedHashMapPut(b *testing.B) {
+	m := NewLinked[string, int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Put("foo", x)
+	}
+}
+
+func BenchmarkLinkedHashMapGet(b *testing.B) {
+	m := NewLinkedByAccess[string, int]()
+
+	for x := 0; x < 1_000_000; x++ {
+		m.Put(strconv.Itoa(x), x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Get("500000")
+	}
+}
+
+func BenchmarkLinkedHashMapForEach(b *testing.B) {
+	m := NewLinked[int, int]()
+
+	for x := 0; x < 1_000_000; x++ {
+		m.Put(x, x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.ForEach(func(key int, val int) {})
+	}
+}
@@ -0,0 +1,35 @@
+// Package bag holds helpers shared by the collections for comparing their
+// values without regard to order.
+package bag
+
+import (
+	"github.com/nomad-software/goad/hashmap"
+)
+
+// Equal returns true if both slices contain the same values the same amount of
+// times, false if not. The order of the values within each slice is ignored.
+func Equal[T comparable](a []T, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := hashmap.New[T, int]()
+	for _, v := range a {
+		counts.Merge(v, 1, func(old int, n int) int {
+			return old + n
+		})
+	}
+
+	for _, v := range b {
+		ok := true
+		counts.Compute(v, func(old int, found bool) (int, bool) {
+			ok = found
+			return old - 1, old > 1
+		})
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,17 @@
+package bag
+
+import (
+	"testing"
+
+	"github.com/nomad-software/assert"
+)
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Equal([]int{}, []int{}))
+	assert.True(t, Equal([]int{1, 2, 2, 3}, []int{2, 3, 2, 1}))
+	assert.False(t, Equal([]int{1, 2, 2}, []int{1, 1, 2}))
+	assert.False(t, Equal([]int{1, 2}, []int{1, 2, 2}))
+	assert.False(t, Equal([]int{1, 2, 3}, []int{1, 2, 4}))
+}
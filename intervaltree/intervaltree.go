@@ -0,0 +1,369 @@
+package intervaltree
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// Interval is a closed interval between two keys.
+type Interval[K constraints.Ordered] struct {
+	Start K
+	End   K
+}
+
+// Overlaps returns true if the passed interval shares at least one point with
+// this interval, false if not.
+func (i Interval[K]) Overlaps(other Interval[K]) bool {
+	return i.Start <= other.End && other.Start <= i.End
+}
+
+// Contains returns true if the passed point lies within the interval, false if
+// not.
+func (i Interval[K]) Contains(point K) bool {
+	return i.Start <= point && point <= i.End
+}
+
+// Less returns true if this interval sorts before the passed interval.
+func (i Interval[K]) less(other Interval[K]) bool {
+	if i.Start == other.Start {
+		return i.End < other.End
+	}
+	return i.Start < other.Start
+}
+
+// Node is the node type used within the interval tree.
+// Every node holds all the values stored against the same interval and is
+// augmented with the greatest end key found in its subtree.
+type node[K constraints.Ordered, V comparable] struct {
+	left   *node[K, V]
+	right  *node[K, V]
+	iv     Interval[K]
+	vals   []V
+	max    K
+	height int
+}
+
+// IntervalTree is the main interval tree type.
+// Copies made by assignment share the same nodes but keep their own count, so
+// after a change through one the count of the other is wrong. Use Clone to
+// copy the nodes.
+type IntervalTree[K constraints.Ordered, V comparable] struct {
+	root  *node[K, V]
+	count int
+}
+
+// New is used to create a new interval tree.
+func New[K constraints.Ordered, V comparable]() IntervalTree[K, V] {
+	return IntervalTree[K, V]{}
+}
+
+// Count returns the amount of entries in the interval tree.
+func (t IntervalTree[K, V]) Count() int {
+	return t.count
+}
+
+// Empty returns true if the interval tree is empty, false if not.
+func (t IntervalTree[K, V]) Empty() bool {
+	return t.Count() == 0
+}
+
+// Height returns the height of the passed node.
+func height[K constraints.Ordered, V comparable](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// Update recalculates the height and the max key of the passed node from its
+// children.
+func (n *node[K, V]) update() {
+	n.height = 1 + max(height(n.left), height(n.right))
+	n.max = n.iv.End
+	if n.left != nil && n.left.max > n.max {
+		n.max = n.left.max
+	}
+	if n.right != nil && n.right.max > n.max {
+		n.max = n.right.max
+	}
+}
+
+// RotateLeft rotates the passed node left, returning the new subtree root.
+func rotateLeft[K constraints.Ordered, V comparable](n *node[K, V]) *node[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+// RotateRight rotates the passed node right, returning the new subtree root.
+func rotateRight[K constraints.Ordered, V comparable](n *node[K, V]) *node[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+// Balance restores the AVL balance of the passed node, returning the new
+// subtree root.
+func balance[K constraints.Ordered, V comparable](n *node[K, V]) *node[K, V] {
+	n.update()
+
+	if height(n.left)-height(n.right) > 1 {
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	}
+
+	if height(n.right)-height(n.left) > 1 {
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+
+	return n
+}
+
+// Insert inserts a value into the tree against the passed interval.
+func (t *IntervalTree[K, V]) Insert(start K, end K, val V) {
+	if start > end {
+		panic("interval start is greater than its end")
+	}
+	t.root = t.insert(t.root, Interval[K]{Start: start, End: end}, val)
+	t.count++
+}
+
+// Insert recursively inserts the value into the passed subtree, returning the
+// new subtree root.
+func (t *IntervalTree[K, V]) insert(n *node[K, V], iv Interval[K], val V) *node[K, V] {
+	if n == nil {
+		n = &node[K, V]{iv: iv, vals: []V{val}}
+		n.update()
+		return n
+	}
+
+	if iv == n.iv {
+		n.vals = append(n.vals, val)
+		return n
+	}
+
+	if iv.less(n.iv) {
+		n.left = t.insert(n.left, iv, val)
+	} else {
+		n.right = t.insert(n.right, iv, val)
+	}
+
+	return balance(n)
+}
+
+// Delete removes a value stored against the passed interval. Returns true if
+// the value was found and removed, false if not.
+func (t *IntervalTree[K, V]) Delete(start K, end K, val V) bool {
+	var removed bool
+	t.root = t.delete(t.root, Interval[K]{Start: start, End: end}, val, &removed)
+	if removed {
+		t.count--
+	}
+	return removed
+}
+
+// Delete recursively removes the value from the passed subtree, returning the
+// new subtree root.
+func (t *IntervalTree[K, V]) delete(n *node[K, V], iv Interval[K], val V, removed *bool) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+
+	if iv == n.iv {
+		for i, v := range n.vals {
+			if v == val {
+				n.vals = append(n.vals[:i], n.vals[i+1:]...)
+				*removed = true
+				break
+			}
+		}
+
+		if len(n.vals) > 0 {
+			return n
+		}
+
+		if n.left == nil {
+			return n.right
+		}
+
+		if n.right == nil {
+			return n.left
+		}
+
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+
+		n.iv = successor.iv
+		n.vals = successor.vals
+		n.right = removeMin(n.right)
+
+		return balance(n)
+	}
+
+	if iv.less(n.iv) {
+		n.left = t.delete(n.left, iv, val, removed)
+	} else {
+		n.right = t.delete(n.right, iv, val, removed)
+	}
+
+	return balance(n)
+}
+
+// RemoveMin removes the smallest node from the passed subtree, returning the
+// new subtree root.
+func removeMin[K constraints.Ordered, V comparable](n *node[K, V]) *node[K, V] {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = removeMin(n.left)
+	return balance(n)
+}
+
+// Contains returns true if the value is stored against the passed interval,
+// false if not.
+func (t IntervalTree[K, V]) Contains(start K, end K, val V) bool {
+	iv := Interval[K]{Start: start, End: end}
+
+	for n := t.root; n != nil; {
+		if iv == n.iv {
+			for _, v := range n.vals {
+				if v == val {
+					return true
+				}
+			}
+			return false
+		}
+
+		if iv.less(n.iv) {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return false
+}
+
+// Overlapping calls the passed function for each value whose interval shares
+// at least one point with the passed interval. Values are delivered in
+// interval order.
+func (t IntervalTree[K, V]) Overlapping(start K, end K, f func(iv Interval[K], val V)) {
+	overlapping(t.root, Interval[K]{Start: start, End: end}, f)
+}
+
+// Overlapping recursively searches the passed subtree for overlapping
+// intervals, skipping any subtree that can't contain a match.
+func overlapping[K constraints.Ordered, V comparable](n *node[K, V], q Interval[K], f func(iv Interval[K], val V)) {
+	if n == nil || n.max < q.Start {
+		return
+	}
+
+	overlapping(n.left, q, f)
+
+	if n.iv.Start > q.End {
+		return
+	}
+
+	if n.iv.Overlaps(q) {
+		for _, v := range n.vals {
+			f(n.iv, v)
+		}
+	}
+
+	overlapping(n.right, q, f)
+}
+
+// Stabbing calls the passed function for each value whose interval contains
+// the passed point. Values are delivered in interval order.
+func (t IntervalTree[K, V]) Stabbing(point K, f func(iv Interval[K], val V)) {
+	t.Overlapping(point, point, f)
+}
+
+// Clear empties the entire interval tree.
+func (t *IntervalTree[K, V]) Clear() {
+	t.root = nil
+	t.count = 0
+}
+
+// ForEach iterates over the dataset within the interval tree in interval
+// order, calling the passed function for each value.
+func (t IntervalTree[K, V]) ForEach(f func(iv Interval[K], val V)) {
+	forEach(t.root, f)
+}
+
+// ForEach recursively walks the passed subtree in order.
+func forEach[K constraints.Ordered, V comparable](n *node[K, V], f func(iv Interval[K], val V)) {
+	if n == nil {
+		return
+	}
+	forEach(n.left, f)
+	for _, v := range n.vals {
+		f(n.iv, v)
+	}
+	forEach(n.right, f)
+}
+
+// Clone returns a copy of the interval tree that shares no nodes with the
+// original.
+func (t IntervalTree[K, V]) Clone() IntervalTree[K, V] {
+	return IntervalTree[K, V]{
+		root:  clone(t.root),
+		count: t.count,
+	}
+}
+
+// Clone recursively copies the passed subtree.
+func clone[K constraints.Ordered, V comparable](n *node[K, V]) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+
+	c := *n
+	c.vals = slices.Clone(n.vals)
+	c.left = clone(n.left)
+	c.right = clone(n.right)
+
+	return &c
+}
+
+// Equal returns true if both interval trees contain the same values stored
+// against the same intervals, false if not.
+func (t IntervalTree[K, V]) Equal(other IntervalTree[K, V]) bool {
+	if t.Count() != other.Count() {
+		return false
+	}
+
+	type entry struct {
+		iv  Interval[K]
+		val V
+	}
+
+	entries := make([]entry, 0, t.Count())
+	t.ForEach(func(iv Interval[K], val V) {
+		entries = append(entries, entry{iv: iv, val: val})
+	})
+
+	var i int
+	result := true
+	other.ForEach(func(iv Interval[K], val V) {
+		if entries[i] != (entry{iv: iv, val: val}) {
+			result = false
+		}
+		i++
+	})
+
+	return result
+}
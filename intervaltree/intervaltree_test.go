@@ -0,0 +1,261 @@
+package intervaltree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nomad-software/assert"
+)
+
+func collect(f func(func(iv Interval[int], val string))) []string {
+	result := make([]string, 0)
+	f(func(iv Interval[int], val string) {
+		result = append(result, val)
+	})
+	return result
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tree := New[int, string]()
+	assert.True(t, tree.Empty())
+
+	tree.Insert(15, 20, "a")
+	tree.Insert(10, 30, "b")
+	tree.Insert(17, 19, "c")
+	tree.Insert(5, 20, "d")
+	tree.Insert(12, 15, "e")
+	tree.Insert(30, 40, "f")
+	tree.Insert(30, 40, "g")
+
+	assert.False(t, tree.Empty())
+	assert.Eq(t, tree.Count(), 7)
+	assert.True(t, tree.Contains(30, 40, "g"))
+	assert.False(t, tree.Contains(30, 40, "h"))
+	assert.False(t, tree.Contains(31, 40, "g"))
+}
+
+func TestFailedInsert(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	tree := New[int, string]()
+	tree.Insert(2, 1, "a")
+}
+
+func TestOverlapping(t *testing.T) {
+	t.Parallel()
+
+	tree := New[int, string]()
+	tree.Insert(15, 20, "a")
+	tree.Insert(10, 30, "b")
+	tree.Insert(17, 19, "c")
+	tree.Insert(5, 20, "d")
+	tree.Insert(12, 15, "e")
+	tree.Insert(30, 40, "f")
+
+	result := collect(func(f func(Interval[int], string)) { tree.Overlapping(6, 7, f) })
+	assert.Eq(t, len(result), 1)
+	assert.Eq(t, result[0], "d")
+
+	result = collect(func(f func(Interval[int], string)) { tree.Overlapping(16, 16, f) })
+	assert.Eq(t, len(result), 3)
+	assert.Eq(t, result[0], "d")
+	assert.Eq(t, result[1], "b")
+	assert.Eq(t, result[2], "a")
+
+	result = collect(func(f func(Interval[int], string)) { tree.Overlapping(30, 31, f) })
+	assert.Eq(t, len(result), 2)
+	assert.Eq(t, result[0], "b")
+	assert.Eq(t, result[1], "f")
+
+	result = collect(func(f func(Interval[int], string)) { tree.Overlapping(41, 50, f) })
+	assert.Eq(t, len(result), 0)
+}
+
+func TestStabbing(t *testing.T) {
+	t.Parallel()
+
+	tree := New[int, string]()
+	tree.Insert(1, 5, "a")
+	tree.Insert(3, 8, "b")
+	tree.Insert(6, 9, "c")
+
+	result := collect(func(f func(Interval[int], string)) { tree.Stabbing(5, f) })
+	assert.Eq(t, len(result), 2)
+	assert.Eq(t, result[0], "a")
+	assert.Eq(t, result[1], "b")
+
+	result = collect(func(f func(Interval[int], string)) { tree.Stabbing(0, f) })
+	assert.Eq(t, len(result), 0)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	tree := New[int, string]()
+	tree.Insert(15, 20, "a")
+	tree.Insert(10, 30, "b")
+	tree.Insert(17, 19, "c")
+	tree.Insert(5, 20, "d")
+	tree.Insert(12, 15, "e")
+	tree.Insert(30, 40, "f")
+	tree.Insert(30, 40, "g")
+
+	assert.True(t, tree.Delete(10, 30, "b"))
+	assert.False(t, tree.Delete(10, 30, "b"))
+	assert.True(t, tree.Delete(30, 40, "f"))
+	assert.Eq(t, tree.Count(), 5)
+
+	result := collect(func(f func(Interval[int], string)) { tree.Overlapping(25, 35, f) })
+	assert.Eq(t, len(result), 1)
+	assert.Eq(t, result[0], "g")
+}
+
+func TestClearing(t *testing.T) {
+	t.Parallel()
+
+	tree := New[int, int]()
+	tree.Insert(1, 2, 1)
+	tree.Insert(3, 4, 2)
+
+	tree.Clear()
+	assert.True(t, tree.Empty())
+
+	tree.Insert(1, 2, 1)
+	assert.Eq(t, tree.Count(), 1)
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	tree := New[int, int]()
+	tree.Insert(5, 6, 3)
+	tree.Insert(1, 9, 1)
+	tree.Insert(3, 4, 2)
+	tree.Insert(7, 8, 4)
+
+	i := 1
+	tree.ForEach(func(iv Interval[int], val int) {
+		assert.Eq(t, val, i)
+		i++
+	})
+
+	tree.Clear()
+	tree.ForEach(func(iv Interval[int], val int) {
+		t.Errorf("interval tree not cleared")
+	})
+}
+
+func TestRandomised(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+	tree := New[int, int]()
+	intervals := make([]Interval[int], 0)
+
+	for i := 0; i < 2_000; i++ {
+		start := r.Intn(10_000)
+		iv := Interval[int]{Start: start, End: start + r.Intn(100)}
+		intervals = append(intervals, iv)
+		tree.Insert(iv.Start, iv.End, i)
+	}
+
+	for i := 0; i < 1_000; i += 2 {
+		assert.True(t, tree.Delete(intervals[i].Start, intervals[i].End, i))
+	}
+
+	assert.Eq(t, tree.Count(), 1_500)
+	assert.True(t, tree.root.height <= 16)
+
+	for q := 0; q < 200; q++ {
+		start := r.Intn(10_000)
+		query := Interval[int]{Start: start, End: start + r.Intn(50)}
+
+		expected := 0
+		for i, iv := range intervals {
+			if (i >= 1_000 || i%2 == 1) && iv.Overlaps(query) {
+				expected++
+			}
+		}
+
+		var found int
+		tree.Overlapping(query.Start, query.End, func(iv Interval[int], val int) {
+			assert.True(t, iv.Overlaps(query))
+			found++
+		})
+
+		assert.Eq(t, found, expected)
+	}
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	tree := New[int, string]()
+	tree.Insert(1, 5, "a")
+	tree.Insert(3, 8, "b")
+	tree.Insert(3, 8, "c")
+
+	c := tree.Clone()
+	assert.True(t, c.Equal(tree))
+
+	c.Delete(3, 8, "b")
+	tree.Insert(6, 9, "d")
+
+	assert.False(t, c.Equal(tree))
+	assert.Eq(t, tree.Count(), 4)
+	assert.Eq(t, c.Count(), 2)
+	assert.True(t, tree.Contains(3, 8, "b"))
+	assert.False(t, c.Contains(6, 9, "d"))
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := New[int, string]()
+	b := New[int, string]()
+	assert.True(t, a.Equal(b))
+
+	a.Insert(1, 5, "a")
+	a.Insert(3, 8, "b")
+	b.Insert(3, 8, "b")
+	b.Insert(1, 5, "c")
+	assert.False(t, a.Equal(b))
+
+	b.Delete(1, 5, "c")
+	b.Insert(1, 5, "a")
+	assert.True(t, a.Equal(b))
+}
+
+func BenchmarkIntervalTreeInsert(b *testing.B) {
+	tree := New[int, int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		tree.Insert(x, x+10, x)
+	}
+}
+
+func BenchmarkIntervalTreeStabbing(b *testing.B) {
+	tree := New[int, int]()
+
+	for x := 0; x < 1_000_000; x++ {
+		tree.Insert(x, x+10, x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		tree.Stabbing(500_000, func(iv Interval[int], val int) {})
+	}
+}
@@ -0,0 +1,237 @@
+package intrusivelist
+
+// Link holds the links between elements of a list.
+// It must be embedded in a struct for that struct to be held in a list, for
+// example:
+//
+//	type Job struct {
+//		intrusivelist.Link[Job]
+//		ID int
+//	}
+//
+// As the links are part of the element no memory is allocated when an element
+// is inserted. An element can only be held in one list at a time.
+type Link[T any] struct {
+	prev  *T
+	next  *T
+	owner *owner
+}
+
+// Link returns the link itself, allowing structs that embed it to satisfy the
+// Element constraint.
+func (l *Link[T]) link() *Link[T] {
+	return l
+}
+
+// Element is the constraint satisfied by pointers to structs that embed a
+// Link.
+type Element[T any] interface {
+	*T
+	link() *Link[T]
+}
+
+// Owner identifies the list an element belongs to. It is not zero sized so
+// that every owner has a unique address.
+type owner struct {
+	_ byte
+}
+
+// List is the main intrusive list type.
+// The list doesn't own its elements, it only threads them together through
+// their embedded links. The zero value is an empty list ready to use. Copying
+// a list by assignment gives a second handle on the same elements with its own
+// ends and count, so inserting or removing through one leaves the other
+// inconsistent. There is no Clone, as elements can only be in one list.
+type List[T any, P Element[T]] struct {
+	first *T
+	last  *T
+	count int
+	owner *owner
+}
+
+// New is used to create a new list of elements of the passed struct type.
+func New[T any, P Element[T]]() List[T, P] {
+	return List[T, P]{
+		owner: &owner{},
+	}
+}
+
+// Count returns the amount of elements in the list.
+func (l List[T, P]) Count() int {
+	return l.count
+}
+
+// Empty returns true if the list is empty, false if not.
+func (l List[T, P]) Empty() bool {
+	return l.Count() == 0
+}
+
+// Links returns the embedded links of the passed element.
+func links[T any, P Element[T]](e *T) *Link[T] {
+	return P(e).link()
+}
+
+// Contains returns true if the element is held in this list, false if not.
+// This takes constant time.
+func (l List[T, P]) Contains(e *T) bool {
+	return l.owner != nil && links[T, P](e).owner == l.owner
+}
+
+// First returns the element at the beginning of the list, or nil if the list
+// is empty.
+func (l List[T, P]) First() *T {
+	return l.first
+}
+
+// Last returns the element at the end of the list, or nil if the list is
+// empty.
+func (l List[T, P]) Last() *T {
+	return l.last
+}
+
+// Next returns the element after the passed element, or nil if it is the last.
+func (l List[T, P]) Next(e *T) *T {
+	return links[T, P](e).next
+}
+
+// Prev returns the element before the passed element, or nil if it is the
+// first.
+func (l List[T, P]) Prev(e *T) *T {
+	return links[T, P](e).prev
+}
+
+// Insert links the element between the passed neighbours, either of which may
+// be nil.
+func (l *List[T, P]) insert(e *T, prev *T, next *T) {
+	link := links[T, P](e)
+	if link.owner != nil {
+		panic("element already in a list")
+	}
+
+	// A zero value list has no owner until its first insertion.
+	if l.owner == nil {
+		l.owner = &owner{}
+	}
+
+	link.prev = prev
+	link.next = next
+	link.owner = l.owner
+
+	if prev == nil {
+		l.first = e
+	} else {
+		links[T, P](prev).next = e
+	}
+
+	if next == nil {
+		l.last = e
+	} else {
+		links[T, P](next).prev = e
+	}
+
+	l.count++
+}
+
+// Must panics if the passed element isn't held in this list.
+func (l List[T, P]) must(e *T) {
+	if !l.Contains(e) {
+		panic("element not found in list")
+	}
+}
+
+// InsertFirst inserts an element at the beginning of the list.
+func (l *List[T, P]) InsertFirst(e *T) {
+	l.insert(e, nil, l.first)
+}
+
+// InsertLast inserts an element at the end of the list.
+func (l *List[T, P]) InsertLast(e *T) {
+	l.insert(e, l.last, nil)
+}
+
+// InsertBefore inserts an element before the passed mark, which must already
+// be held in this list.
+func (l *List[T, P]) InsertBefore(e *T, mark *T) {
+	l.must(mark)
+	l.insert(e, links[T, P](mark).prev, mark)
+}
+
+// InsertAfter inserts an element after the passed mark, which must already be
+// held in this list.
+func (l *List[T, P]) InsertAfter(e *T, mark *T) {
+	l.must(mark)
+	l.insert(e, mark, links[T, P](mark).next)
+}
+
+// Remove removes an element from the list in constant time. Once removed the
+// element may be inserted into any list again.
+func (l *List[T, P]) Remove(e *T) {
+	l.must(e)
+	link := links[T, P](e)
+
+	if link.prev == nil {
+		l.first = link.next
+	} else {
+		links[T, P](link.prev).next = link.next
+	}
+
+	if link.next == nil {
+		l.last = link.prev
+	} else {
+		links[T, P](link.next).prev = link.prev
+	}
+
+	link.prev = nil
+	link.next = nil
+	link.owner = nil
+	l.count--
+}
+
+// RemoveFirst removes and returns the element at the beginning of the list, or
+// returns nil if the list is empty.
+func (l *List[T, P]) RemoveFirst() *T {
+	e := l.first
+	if e != nil {
+		l.Remove(e)
+	}
+	return e
+}
+
+// RemoveLast removes and returns the element at the end of the list, or
+// returns nil if the list is empty.
+func (l *List[T, P]) RemoveLast() *T {
+	e := l.last
+	if e != nil {
+		l.Remove(e)
+	}
+	return e
+}
+
+// Clear removes every element from the list, unlinking each one so it may be
+// inserted into a list again.
+func (l *List[T, P]) Clear() {
+	for e := l.first; e != nil; {
+		link := links[T, P](e)
+		e = link.next
+
+		link.prev = nil
+		link.next = nil
+		link.owner = nil
+	}
+
+	l.first = nil
+	l.last = nil
+	l.count = 0
+}
+
+// ForEach iterates over the elements within the list, calling the passed
+// function for each element. The passed function must not remove elements
+// from the list.
+func (l List[T, P]) ForEach(f func(i int, e *T)) {
+	var index int
+
+	for e := l.first; e != nil; e = links[T, P](e).next {
+		f(index, e)
+		index++
+	}
+}
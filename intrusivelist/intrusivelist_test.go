@@ -0,0 +1,246 @@
+package intrusivelist
+
+import (
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"github.com/nomad-software/goad/linkedlist"
+)
+
+type job struct {
+	Link[job]
+	id int
+}
+
+// Ids returns the ids of the elements in the list, walking forwards and then
+// backwards to check the links are consistent.
+func ids(t *testing.T, l List[job, *job]) []int {
+	forwards := make([]int, 0, l.Count())
+	for e := l.First(); e != nil; e = l.Next(e) {
+		forwards = append(forwards, e.id)
+	}
+
+	i := len(forwards)
+	for e := l.Last(); e != nil; e = l.Prev(e) {
+		i--
+		assert.Eq(t, e.id, forwards[i])
+	}
+	assert.Eq(t, i, 0)
+	assert.Eq(t, len(forwards), l.Count())
+
+	return forwards
+}
+
+func equal(a []int, b ...int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	l := New[job]()
+	assert.True(t, l.Empty())
+	assert.Eq(t, l.Count(), 0)
+	assert.True(t, l.First() == nil)
+	assert.True(t, l.Last() == nil)
+}
+
+func TestZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var l List[job, *job]
+	a := &job{id: 1}
+	b := &job{id: 2}
+
+	assert.True(t, l.Empty())
+	assert.False(t, l.Contains(a))
+
+	l.InsertLast(a)
+	l.InsertLast(b)
+	assert.True(t, l.Contains(a))
+	assert.True(t, equal(ids(t, l), 1, 2))
+
+	l.Remove(a)
+	assert.False(t, l.Contains(a))
+	assert.True(t, equal(ids(t, l), 2))
+
+	// A second insertion of the same element is still detected.
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	l.InsertFirst(b)
+}
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	l := New[job]()
+	jobs := []job{{id: 0}, {id: 1}, {id: 2}, {id: 3}, {id: 4}}
+
+	l.InsertLast(&jobs[2])
+	l.InsertFirst(&jobs[0])
+	l.InsertLast(&jobs[4])
+	l.InsertAfter(&jobs[1], &jobs[0])
+	l.InsertBefore(&jobs[3], &jobs[4])
+
+	assert.True(t, equal(ids(t, l), 0, 1, 2, 3, 4))
+	assert.Eq(t, l.First(), &jobs[0])
+	assert.Eq(t, l.Last(), &jobs[4])
+
+	for i := range jobs {
+		assert.True(t, l.Contains(&jobs[i]))
+	}
+}
+
+func TestFailedInsert(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	a := New[job]()
+	b := New[job]()
+	j := &job{id: 1}
+
+	a.InsertLast(j)
+	b.InsertLast(j)
+}
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	l := New[job]()
+	jobs := []job{{id: 0}, {id: 1}, {id: 2}, {id: 3}}
+	for i := range jobs {
+		l.InsertLast(&jobs[i])
+	}
+
+	l.Remove(&jobs[1])
+	assert.True(t, equal(ids(t, l), 0, 2, 3))
+	assert.False(t, l.Contains(&jobs[1]))
+
+	assert.Eq(t, l.RemoveFirst(), &jobs[0])
+	assert.Eq(t, l.RemoveLast(), &jobs[3])
+	assert.True(t, equal(ids(t, l), 2))
+
+	assert.Eq(t, l.RemoveLast(), &jobs[2])
+	assert.True(t, l.Empty())
+	assert.True(t, l.RemoveFirst() == nil)
+	assert.True(t, l.RemoveLast() == nil)
+
+	// Removed elements can be inserted into another list.
+	o := New[job]()
+	o.InsertLast(&jobs[1])
+	o.InsertLast(&jobs[0])
+	assert.True(t, equal(ids(t, o), 1, 0))
+	assert.False(t, l.Contains(&jobs[1]))
+}
+
+func TestFailedRemove(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	a := New[job]()
+	b := New[job]()
+	j := &job{id: 1}
+
+	a.InsertLast(j)
+	b.Remove(j)
+}
+
+func TestClear(t *testing.T) {
+	t.Parallel()
+
+	l := New[job]()
+	jobs := []job{{id: 0}, {id: 1}}
+	for i := range jobs {
+		l.InsertLast(&jobs[i])
+	}
+
+	l.Clear()
+	assert.True(t, l.Empty())
+	assert.False(t, l.Contains(&jobs[0]))
+
+	l.InsertLast(&jobs[1])
+	l.InsertLast(&jobs[0])
+	assert.True(t, equal(ids(t, l), 1, 0))
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	l := New[job]()
+	jobs := []job{{id: 0}, {id: 1}, {id: 2}}
+	for i := range jobs {
+		l.InsertLast(&jobs[i])
+	}
+
+	var count int
+	l.ForEach(func(i int, e *job) {
+		assert.Eq(t, e.id, i)
+		count++
+	})
+	assert.Eq(t, count, 3)
+}
+
+func BenchmarkIntrusiveListInsertAndRemove(b *testing.B) {
+	l := New[job]()
+	j := &job{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		j.id = x
+		l.InsertLast(j)
+		l.RemoveLast()
+	}
+}
+
+func BenchmarkLinkedListInsertAndRemove(b *testing.B) {
+	l := linkedlist.New[int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		l.InsertLast(x)
+		l.RemoveLast()
+	}
+}
+
+func BenchmarkIntrusiveListForEach(b *testing.B) {
+	l := New[job]()
+	jobs := make([]job, 1_000_000)
+
+	for x := range jobs {
+		jobs[x].id = x
+		l.InsertLast(&jobs[x])
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		l.ForEach(func(index int, e *job) {})
+	}
+}
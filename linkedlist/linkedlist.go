@@ -8,10 +8,14 @@ type node[T comparable] struct {
 }
 
 // LinkedList is the main linked list type.
+// Assigning a linked list only copies its end nodes and count, so both copies
+// walk the same nodes and a change through one leaves the other with stale
+// ends and a wrong count. Use Clone to copy the nodes.
 type LinkedList[T comparable] struct {
 	first *node[T]
 	last  *node[T]
 	count int
+	pool  *Pool[T]
 }
 
 // New is used to create a new linked list.
@@ -19,6 +23,37 @@ func New[T comparable]() LinkedList[T] {
 	return LinkedList[T]{}
 }
 
+// NewPooled is used to create a new linked list that takes its nodes from the
+// passed pool and returns them to it once removed.
+func NewPooled[T comparable](pool *Pool[T]) LinkedList[T] {
+	return LinkedList[T]{
+		pool: pool,
+	}
+}
+
+// FromSlice is used to create a new linked list from the passed values.
+func FromSlice[T comparable](vals []T) LinkedList[T] {
+	l := New[T]()
+	l.InsertAllAt(0, vals...)
+	return l
+}
+
+// Alloc returns a new node holding the passed value, taken from the pool if
+// the linked list has one.
+func (l *LinkedList[T]) alloc(val T) *node[T] {
+	if l.pool == nil {
+		return &node[T]{val: val}
+	}
+	return l.pool.get(val)
+}
+
+// Free returns a removed node to the pool if the linked list has one.
+func (l *LinkedList[T]) free(n *node[T]) {
+	if l.pool != nil {
+		l.pool.put(n)
+	}
+}
+
 // Count returns the amount of entries in the linked list.
 func (l LinkedList[T]) Count() int {
 	return l.count
@@ -31,7 +66,7 @@ func (l LinkedList[T]) Empty() bool {
 
 // InsertFirst inserts a value at the beginning of the linked list.
 func (l *LinkedList[T]) InsertFirst(val T) {
-	n := &node[T]{val: val}
+	n := l.alloc(val)
 
 	if l.first == nil {
 		l.first = n
@@ -56,22 +91,55 @@ func (l LinkedList[T]) First() T {
 
 // RemoveFirst removes the first value in the linked list.
 func (l *LinkedList[T]) RemoveFirst() {
-	if l.first != nil {
-		if l.first.next == nil {
+	if n := l.first; n != nil {
+		if n.next == nil {
 			l.first = nil
 			l.last = nil
 		} else {
-			l.first = l.first.next
+			l.first = n.next
 			l.first.prev = nil
 		}
+		l.free(n)
+	}
+
+	l.count--
+}
+
+// MoveFirst moves the first value in the linked list to the end of the passed
+// linked list. No values are copied and no nodes are allocated, so this takes
+// constant time. Both linked lists must use the same pool.
+func (l *LinkedList[T]) MoveFirst(other *LinkedList[T]) {
+	n := l.first
+	if n == nil {
+		panic("linked list empty, moving first failed")
+	}
+
+	if other.pool != l.pool {
+		panic("linked lists use different pools, moving first failed")
 	}
 
+	l.first = n.next
+	if l.first == nil {
+		l.last = nil
+	} else {
+		l.first.prev = nil
+	}
 	l.count--
+
+	n.next = nil
+	n.prev = other.last
+	if other.last == nil {
+		other.first = n
+	} else {
+		other.last.next = n
+	}
+	other.last = n
+	other.count++
 }
 
 // InsertLast inserts a value at the end of the linked list.
 func (l *LinkedList[T]) InsertLast(val T) {
-	n := &node[T]{val: val}
+	n := l.alloc(val)
 
 	if l.last == nil {
 		l.first = n
@@ -96,14 +164,15 @@ func (l LinkedList[T]) Last() T {
 
 // RemoveLast removes the last value in the linked list.
 func (l *LinkedList[T]) RemoveLast() {
-	if l.last != nil {
-		if l.last.prev == nil {
+	if n := l.last; n != nil {
+		if n.prev == nil {
 			l.first = nil
 			l.last = nil
 		} else {
-			l.last = l.last.prev
+			l.last = n.prev
 			l.last.next = nil
 		}
+		l.free(n)
 	}
 
 	l.count--
@@ -122,7 +191,7 @@ func (l *LinkedList[T]) Insert(val T, index int) {
 		l.InsertLast(val)
 
 	} else {
-		n := &node[T]{val: val}
+		n := l.alloc(val)
 
 		var listIndex int = 0
 		for ln := l.first; ln != nil; ln = ln.next {
@@ -140,6 +209,54 @@ func (l *LinkedList[T]) Insert(val T, index int) {
 	}
 }
 
+// InsertAllAt inserts the passed values in order, starting at the specified
+// index. The list is only walked once regardless of how many values are
+// inserted.
+func (l *LinkedList[T]) InsertAllAt(index int, vals ...T) {
+	if index > l.Count() {
+		panic("Insertion index invalid")
+	}
+
+	if len(vals) == 0 {
+		return
+	}
+
+	first := l.alloc(vals[0])
+	last := first
+	for _, v := range vals[1:] {
+		n := l.alloc(v)
+		n.prev = last
+		last.next = n
+		last = n
+	}
+
+	var next *node[T]
+	prev := l.last
+	if index < l.Count() {
+		next = l.first
+		for i := 0; i < index; i++ {
+			next = next.next
+		}
+		prev = next.prev
+	}
+
+	first.prev = prev
+	if prev == nil {
+		l.first = first
+	} else {
+		prev.next = first
+	}
+
+	last.next = next
+	if next == nil {
+		l.last = last
+	} else {
+		next.prev = last
+	}
+
+	l.count += len(vals)
+}
+
 // Get gets a value at the specified index.
 func (l LinkedList[T]) Get(index int) T {
 	if index >= l.Count() {
@@ -203,6 +320,7 @@ func (l *LinkedList[T]) Remove(index int) {
 			if listIndex == index {
 				ln.prev.next = ln.next
 				ln.next.prev = ln.prev
+				l.free(ln)
 				break
 			}
 			listIndex++
@@ -223,8 +341,24 @@ func (l LinkedList[T]) Contains(val T) bool {
 	return false
 }
 
-// Clear empties the entire linked list.
+// Drain removes every value from the linked list, returning them in order.
+func (l *LinkedList[T]) Drain() []T {
+	vals := l.ToSlice()
+	l.Clear()
+	return vals
+}
+
+// Clear empties the entire linked list. If the linked list has a pool every
+// node is returned to it, otherwise this takes constant time.
 func (l *LinkedList[T]) Clear() {
+	if l.pool != nil {
+		for ln := l.first; ln != nil; {
+			next := ln.next
+			l.pool.put(ln)
+			ln = next
+		}
+	}
+
 	l.first = nil
 	l.last = nil
 	l.count = 0
@@ -240,3 +374,337 @@ func (l LinkedList[T]) ForEach(f func(i int, val T)) {
 		index++
 	}
 }
+
+// ToSlice returns the values of the linked list in order.
+func (l LinkedList[T]) ToSlice() []T {
+	vals := make([]T, 0, l.Count())
+	for ln := l.first; ln != nil; ln = ln.next {
+		vals = append(vals, ln.val)
+	}
+	return vals
+}
+
+// ForEachWhile iterates over the dataset within the linked list, calling the
+// passed function for each value until it returns false.
+func (l LinkedList[T]) ForEachWhile(f func(i int, val T) bool) {
+	var index int
+
+	for ln := l.first; ln != nil; ln = ln.next {
+		if !f(index, ln.val) {
+			return
+		}
+		index++
+	}
+}
+
+// Modify iterates over the dataset within the linked list, calling the passed
+// function with a pointer to each value so it can be changed in place. The
+// function returns whether the value should be removed and whether iterating
+// should stop, allowing a value to be found and then updated or removed in a
+// single walk.
+func (l *LinkedList[T]) Modify(f func(val *T) (remove bool, stop bool)) {
+	for ln := l.first; ln != nil; {
+		next := ln.next
+		remove, stop := f(&ln.val)
+
+		if remove {
+			l.unlink(ln)
+		}
+
+		if stop {
+			return
+		}
+		ln = next
+	}
+}
+
+// Node returns the node at the specified index, walking from whichever end of
+// the linked list is closer.
+func (l LinkedList[T]) node(index int) *node[T] {
+	if index < l.count/2 {
+		n := l.first
+		for i := 0; i < index; i++ {
+			n = n.next
+		}
+		return n
+	}
+
+	n := l.last
+	for i := l.count - 1; i > index; i-- {
+		n = n.prev
+	}
+	return n
+}
+
+// Unlink removes the passed node from the linked list, returning it to the
+// pool if the linked list has one.
+func (l *LinkedList[T]) unlink(n *node[T]) {
+	if n.prev == nil {
+		l.first = n.next
+	} else {
+		n.prev.next = n.next
+	}
+
+	if n.next == nil {
+		l.last = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+
+	l.free(n)
+	l.count--
+}
+
+// IndexOf returns the index of the first occurrence of the value, or -1 if the
+// value doesn't exist in the linked list.
+func (l LinkedList[T]) IndexOf(val T) int {
+	var index int
+
+	for ln := l.first; ln != nil; ln = ln.next {
+		if ln.val == val {
+			return index
+		}
+		index++
+	}
+
+	return -1
+}
+
+// LastIndexOf returns the index of the last occurrence of the value, or -1 if
+// the value doesn't exist in the linked list.
+func (l LinkedList[T]) LastIndexOf(val T) int {
+	index := l.count - 1
+
+	for ln := l.last; ln != nil; ln = ln.prev {
+		if ln.val == val {
+			return index
+		}
+		index--
+	}
+
+	return -1
+}
+
+// RemoveValue removes the first occurrence of the value, returning true if it
+// was found or false if not.
+func (l *LinkedList[T]) RemoveValue(val T) bool {
+	for ln := l.first; ln != nil; ln = ln.next {
+		if ln.val == val {
+			l.unlink(ln)
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoveAll removes every value for which the passed predicate returns true,
+// returning the amount of values removed.
+func (l *LinkedList[T]) RemoveAll(pred func(val T) bool) int {
+	var removed int
+
+	for ln := l.first; ln != nil; {
+		next := ln.next
+		if pred(ln.val) {
+			l.unlink(ln)
+			removed++
+		}
+		ln = next
+	}
+
+	return removed
+}
+
+// Reverse reverses the order of the values in the linked list in place.
+func (l *LinkedList[T]) Reverse() {
+	for ln := l.first; ln != nil; ln = ln.prev {
+		ln.prev, ln.next = ln.next, ln.prev
+	}
+
+	l.first, l.last = l.last, l.first
+}
+
+// Splice moves every value from the passed linked list into this one,
+// starting at the specified index, leaving the passed linked list empty. No
+// values are copied, so once the index is found this takes constant time. If
+// the linked lists use different pools the values are copied into nodes from
+// this list's pool instead, which takes linear time.
+func (l *LinkedList[T]) Splice(other *LinkedList[T], index int) {
+	if index > l.Count() {
+		panic("Insertion index invalid")
+	}
+
+	if other == l {
+		panic("can't splice a linked list into itself")
+	}
+
+	if other.first == nil {
+		return
+	}
+
+	// Nodes must always be returned to the pool they came from.
+	if other.pool != l.pool {
+		copied := NewPooled(l.pool)
+		for n := other.first; n != nil; n = n.next {
+			copied.InsertLast(n.val)
+		}
+		other.Clear()
+		other = &copied
+	}
+
+	var next *node[T]
+	prev := l.last
+	if index < l.Count() {
+		next = l.node(index)
+		prev = next.prev
+	}
+
+	other.first.prev = prev
+	if prev == nil {
+		l.first = other.first
+	} else {
+		prev.next = other.first
+	}
+
+	other.last.next = next
+	if next == nil {
+		l.last = other.last
+	} else {
+		next.prev = other.last
+	}
+
+	l.count += other.count
+
+	// The nodes now belong to this linked list, so they mustn't be returned to
+	// a pool by clearing the other.
+	other.first = nil
+	other.last = nil
+	other.count = 0
+}
+
+// Split removes the values from the specified index onwards, returning them
+// as a new linked list. No values are copied, so once the index is found this
+// takes constant time.
+func (l *LinkedList[T]) Split(index int) LinkedList[T] {
+	if index > l.Count() {
+		panic("index outside of linked list bounds")
+	}
+
+	tail := NewPooled(l.pool)
+	if index == l.Count() {
+		return tail
+	}
+
+	n := l.node(index)
+	tail.first = n
+	tail.last = l.last
+	tail.count = l.count - index
+
+	if n.prev == nil {
+		l.first = nil
+	} else {
+		n.prev.next = nil
+	}
+
+	l.last = n.prev
+	l.count = index
+	n.prev = nil
+
+	return tail
+}
+
+// Concat returns a new linked list containing the values of this linked list
+// followed by the values of every passed linked list. None of the linked lists
+// are modified.
+func (l LinkedList[T]) Concat(others ...LinkedList[T]) LinkedList[T] {
+	c := l.Clone()
+	for _, other := range others {
+		for ln := other.first; ln != nil; ln = ln.next {
+			c.InsertLast(ln.val)
+		}
+	}
+	return c
+}
+
+// Sort sorts the linked list in place using a stable merge sort. The passed
+// function is a predicate that returns true if the first parameter is less
+// than the second. No values are copied, only the links between them.
+func (l *LinkedList[T]) Sort(less func(a T, b T) bool) {
+	l.first = mergeSort(l.first, l.count, less)
+
+	var prev *node[T]
+	for ln := l.first; ln != nil; ln = ln.next {
+		ln.prev = prev
+		prev = ln
+	}
+	l.last = prev
+}
+
+// MergeSort sorts the passed amount of nodes starting at the passed node,
+// returning the new first node. Only the next links are maintained.
+func mergeSort[T comparable](first *node[T], count int, less func(a T, b T) bool) *node[T] {
+	if count < 2 {
+		if first != nil {
+			first.next = nil
+		}
+		return first
+	}
+
+	half := count / 2
+	second := first
+	for i := 0; i < half; i++ {
+		second = second.next
+	}
+
+	a := mergeSort(first, half, less)
+	b := mergeSort(second, count-half, less)
+
+	var head node[T]
+	tail := &head
+
+	for a != nil && b != nil {
+		// Take from a unless b is strictly less, which keeps the sort stable.
+		if less(b.val, a.val) {
+			tail.next = b
+			b = b.next
+		} else {
+			tail.next = a
+			a = a.next
+		}
+		tail = tail.next
+	}
+
+	if a != nil {
+		tail.next = a
+	} else {
+		tail.next = b
+	}
+
+	return head.next
+}
+
+// Clone returns a copy of the linked list that shares no nodes with the
+// original. The copy takes its nodes from the same pool as the original.
+func (l LinkedList[T]) Clone() LinkedList[T] {
+	c := NewPooled(l.pool)
+	for ln := l.first; ln != nil; ln = ln.next {
+		c.InsertLast(ln.val)
+	}
+	return c
+}
+
+// Equal returns true if both linked lists contain the same values in the same
+// order, false if not.
+func (l LinkedList[T]) Equal(other LinkedList[T]) bool {
+	if l.Count() != other.Count() {
+		return false
+	}
+
+	for a, b := l.first, other.first; a != nil; a, b = a.next, b.next {
+		if a.val != b.val {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,9 +1,11 @@
 package linkedlist
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
 )
 
 func TestNew(t *testing.T) {
@@ -430,6 +432,401 @@ func TestForEach(t *testing.T) {
 	})
 }
 
+func TestForEachWhile(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{0, 1, 2, 3, 4})
+
+	var calls int
+	l.ForEachWhile(func(i int, val int) bool {
+		assert.Eq(t, val, i)
+		calls++
+		return val < 2
+	})
+	assert.Eq(t, calls, 3)
+
+	calls = 0
+	l.ForEachWhile(func(i int, val int) bool {
+		calls++
+		return true
+	})
+	assert.Eq(t, calls, 5)
+
+	l.Clear()
+	l.ForEachWhile(func(i int, val int) bool {
+		t.Errorf("linked list not cleared")
+		return true
+	})
+}
+
+func TestInsertAllAt(t *testing.T) {
+	t.Parallel()
+
+	l := New[int]()
+	l.InsertAllAt(0)
+	assert.True(t, l.Empty())
+
+	l.InsertAllAt(0, 3, 4)
+	l.InsertAllAt(0, 1, 2)
+	l.InsertAllAt(4, 7, 8)
+	l.InsertAllAt(4, 5, 6)
+
+	assert.Eq(t, l.Count(), 8)
+	assert.True(t, slices.Equal(l.Drain(), []int{1, 2, 3, 4, 5, 6, 7, 8}))
+
+	l.InsertAllAt(0, 1, 4)
+	l.InsertAllAt(1, 2, 3)
+	assert.Eq(t, l.First(), 1)
+	assert.Eq(t, l.Last(), 4)
+
+	var i int
+	for ln := l.last; ln != nil; ln = ln.prev {
+		assert.Eq(t, ln.val, 4-i)
+		i++
+	}
+	assert.Eq(t, i, l.Count())
+}
+
+func TestFailedInsertAllAt(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	l := New[int]()
+	l.InsertAllAt(1, 1)
+}
+
+func TestDrain(t *testing.T) {
+	t.Parallel()
+
+	l := New[int]()
+	l.InsertAllAt(0, 1, 2, 3)
+
+	assert.True(t, slices.Equal(l.Drain(), []int{1, 2, 3}))
+	assert.True(t, l.Empty())
+	assert.Eq(t, len(l.Drain()), 0)
+}
+
+func TestSlices(t *testing.T) {
+	t.Parallel()
+
+	vals := []int{1, 2, 3}
+	l := FromSlice(vals)
+	vals[0] = 10
+
+	assert.Eq(t, l.Count(), 3)
+	assert.Eq(t, l.First(), 1)
+	assert.Eq(t, l.Last(), 3)
+	assert.True(t, slices.Equal(l.ToSlice(), []int{1, 2, 3}))
+
+	l.Remove(1)
+	assert.True(t, slices.Equal(l.ToSlice(), []int{1, 3}))
+	assert.True(t, FromSlice(l.ToSlice()).Equal(l))
+	assert.True(t, FromSlice[int](nil).Empty())
+}
+
+// Backwards returns the values of the linked list by walking from the end,
+// which checks the prev links are consistent with the next links.
+func backwards[T comparable](l LinkedList[T]) []T {
+	vals := make([]T, 0, l.Count())
+	for ln := l.last; ln != nil; ln = ln.prev {
+		vals = append([]T{ln.val}, vals...)
+	}
+	return vals
+}
+
+func TestIndexOf(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{1, 2, 3, 2, 1})
+
+	assert.Eq(t, l.IndexOf(1), 0)
+	assert.Eq(t, l.IndexOf(2), 1)
+	assert.Eq(t, l.IndexOf(4), -1)
+
+	assert.Eq(t, l.LastIndexOf(1), 4)
+	assert.Eq(t, l.LastIndexOf(3), 2)
+	assert.Eq(t, l.LastIndexOf(4), -1)
+}
+
+func TestRemoveValue(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{1, 2, 3, 2, 1})
+
+	assert.True(t, l.RemoveValue(2))
+	assert.True(t, slices.Equal(l.ToSlice(), []int{1, 3, 2, 1}))
+
+	assert.True(t, l.RemoveValue(1))
+	assert.True(t, l.RemoveValue(1))
+	assert.True(t, slices.Equal(backwards(l), []int{3, 2}))
+	assert.Eq(t, l.Last(), 2)
+
+	assert.False(t, l.RemoveValue(4))
+	assert.Eq(t, l.Count(), 2)
+}
+
+func TestRemoveAll(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{1, 2, 3, 4, 5, 6})
+
+	assert.Eq(t, l.RemoveAll(func(val int) bool { return val%2 == 1 }), 3)
+	assert.True(t, slices.Equal(backwards(l), []int{2, 4, 6}))
+	assert.Eq(t, l.Count(), 3)
+
+	assert.Eq(t, l.RemoveAll(func(val int) bool { return false }), 0)
+	assert.Eq(t, l.RemoveAll(func(val int) bool { return true }), 3)
+	assert.True(t, l.Empty())
+
+	l.InsertLast(1)
+	assert.Eq(t, l.First(), 1)
+}
+
+func TestReverse(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{1, 2, 3, 4})
+	l.Reverse()
+
+	assert.True(t, slices.Equal(l.ToSlice(), []int{4, 3, 2, 1}))
+	assert.True(t, slices.Equal(backwards(l), []int{4, 3, 2, 1}))
+	assert.Eq(t, l.First(), 4)
+	assert.Eq(t, l.Last(), 1)
+
+	e := New[int]()
+	e.Reverse()
+	assert.True(t, e.Empty())
+}
+
+func TestSplice(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{1, 5})
+	o := FromSlice([]int{2, 3, 4})
+
+	l.Splice(&o, 1)
+	assert.True(t, o.Empty())
+	assert.Eq(t, l.Count(), 5)
+	assert.True(t, slices.Equal(backwards(l), []int{1, 2, 3, 4, 5}))
+
+	o.InsertLast(0)
+	l.Splice(&o, 0)
+	o = FromSlice([]int{6, 7})
+	l.Splice(&o, l.Count())
+	l.Splice(&o, 3)
+
+	assert.True(t, slices.Equal(l.ToSlice(), []int{0, 1, 2, 3, 4, 5, 6, 7}))
+	assert.True(t, slices.Equal(backwards(l), []int{0, 1, 2, 3, 4, 5, 6, 7}))
+
+	e := New[int]()
+	o = FromSlice([]int{1})
+	e.Splice(&o, 0)
+	assert.Eq(t, e.First(), 1)
+	assert.Eq(t, e.Last(), 1)
+}
+
+func TestFailedSplice(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	l := FromSlice([]int{1, 2})
+	l.Splice(&l, 1)
+}
+
+func TestModify(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{1, 2, 3, 4, 5, 6})
+
+	l.Modify(func(val *int) (bool, bool) {
+		*val *= 10
+		return *val%20 == 0, false
+	})
+	assert.True(t, slices.Equal(l.ToSlice(), []int{10, 30, 50}))
+	assert.True(t, slices.Equal(backwards(l), []int{10, 30, 50}))
+
+	var calls int
+	l.Modify(func(val *int) (bool, bool) {
+		calls++
+		return *val == 30, *val == 30
+	})
+	assert.Eq(t, calls, 2)
+	assert.True(t, slices.Equal(l.ToSlice(), []int{10, 50}))
+
+	l.Modify(func(val *int) (bool, bool) {
+		return true, false
+	})
+	assert.True(t, l.Empty())
+	assert.True(t, l.first == nil && l.last == nil)
+}
+
+func TestMoveFirst(t *testing.T) {
+	t.Parallel()
+
+	a := FromSlice([]int{1, 2, 3})
+	b := FromSlice([]int{4})
+
+	a.MoveFirst(&b)
+	assert.True(t, slices.Equal(a.ToSlice(), []int{2, 3}))
+	assert.True(t, slices.Equal(b.ToSlice(), []int{4, 1}))
+	assert.True(t, slices.Equal(backwards(b), []int{4, 1}))
+
+	a.MoveFirst(&a)
+	assert.True(t, slices.Equal(a.ToSlice(), []int{3, 2}))
+	assert.True(t, slices.Equal(backwards(a), []int{3, 2}))
+
+	c := New[int]()
+	a.MoveFirst(&c)
+	a.MoveFirst(&c)
+	assert.True(t, a.Empty())
+	assert.True(t, slices.Equal(c.ToSlice(), []int{3, 2}))
+	assert.True(t, slices.Equal(backwards(c), []int{3, 2}))
+}
+
+func TestFailedMoveFirst(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	a := New[int]()
+	b := New[int]()
+	a.MoveFirst(&b)
+}
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{1, 2, 3, 4, 5})
+
+	tail := l.Split(3)
+	assert.True(t, slices.Equal(backwards(l), []int{1, 2, 3}))
+	assert.True(t, slices.Equal(backwards(tail), []int{4, 5}))
+	assert.Eq(t, l.Count(), 3)
+	assert.Eq(t, tail.Count(), 2)
+
+	assert.True(t, l.Split(3).Empty())
+
+	all := l.Split(0)
+	assert.True(t, l.Empty())
+	assert.True(t, slices.Equal(backwards(all), []int{1, 2, 3}))
+
+	all.Splice(&tail, all.Count())
+	assert.True(t, slices.Equal(backwards(all), []int{1, 2, 3, 4, 5}))
+}
+
+func TestFailedSplit(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	l := FromSlice([]int{1, 2})
+	l.Split(3)
+}
+
+func TestConcat(t *testing.T) {
+	t.Parallel()
+
+	a := FromSlice([]int{1, 2})
+	b := FromSlice([]int{3})
+	c := New[int]()
+
+	l := a.Concat(b, c, a)
+	assert.True(t, slices.Equal(backwards(l), []int{1, 2, 3, 1, 2}))
+	assert.Eq(t, a.Count(), 2)
+	assert.Eq(t, b.Count(), 1)
+
+	l.Update(0, 10)
+	assert.Eq(t, a.First(), 1)
+}
+
+func TestSort(t *testing.T) {
+	t.Parallel()
+
+	type pair struct {
+		key int
+		id  int
+	}
+
+	r := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 50; n++ {
+		vals := make([]pair, n)
+		for i := range vals {
+			vals[i] = pair{key: r.Intn(5), id: i}
+		}
+
+		less := func(a, b pair) bool { return a.key < b.key }
+
+		l := FromSlice(vals)
+		l.Sort(less)
+
+		slices.SortStableFunc(vals, less)
+		assert.True(t, slices.Equal(l.ToSlice(), vals))
+		assert.True(t, slices.Equal(backwards(l), vals))
+		assert.Eq(t, l.Count(), n)
+	}
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	l := New[int]()
+	l.InsertLast(1)
+	l.InsertLast(2)
+	l.InsertLast(3)
+
+	c := l.Clone()
+	assert.True(t, c.Equal(l))
+
+	c.RemoveFirst()
+	c.InsertLast(4)
+	l.Update(1, 5)
+
+	assert.False(t, c.Equal(l))
+	assert.Eq(t, l.Count(), 3)
+	assert.Eq(t, c.Count(), 3)
+	assert.Eq(t, l.Get(1), 5)
+	assert.Eq(t, l.Last(), 3)
+	assert.Eq(t, c.First(), 2)
+	assert.Eq(t, c.Last(), 4)
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := New[string]()
+	b := New[string]()
+	assert.True(t, a.Equal(b))
+
+	a.InsertLast("foo")
+	a.InsertLast("bar")
+	b.InsertFirst("foo")
+	b.InsertFirst("bar")
+	assert.False(t, a.Equal(b))
+
+	b.RemoveFirst()
+	b.InsertLast("bar")
+	assert.True(t, a.Equal(b))
+}
+
 func BenchmarkLinkedListInsertAndRemove(b *testing.B) {
 	l := New[int]()
 
@@ -442,6 +839,25 @@ func BenchmarkLinkedListInsertAndRemove(b *testing.B) {
 	}
 }
 
+func BenchmarkLinkedListSort(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	vals := make([]int, 100_000)
+	for i := range vals {
+		vals[i] = r.Int()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		b.StopTimer()
+		l := FromSlice(vals)
+		b.StartTimer()
+
+		l.Sort(func(a, b int) bool { return a < b })
+	}
+}
+
 func BenchmarkLinkedListForEach(b *testing.B) {
 	l := New[int]()
 
@@ -0,0 +1,68 @@
+package linkedlist
+
+// Pool is a free list of linked list nodes, backed by slabs of nodes that are
+// allocated together. Linked lists using a pool take their nodes from it and
+// return them to it once removed, so a list with a steady amount of values
+// stops allocating after warming up. A pool may be shared between many linked
+// lists but isn't safe for concurrent use, so lists sharing a pool must not be
+// modified concurrently.
+type Pool[T comparable] struct {
+	free      *node[T]
+	slab      []node[T]
+	slabSize  int
+	available int
+}
+
+// NewPool is used to create a new pool which allocates nodes in slabs of the
+// passed size.
+func NewPool[T comparable](slabSize int) *Pool[T] {
+	if slabSize < 1 {
+		panic("pool slab size must be greater than zero")
+	}
+
+	return &Pool[T]{
+		slabSize: slabSize,
+	}
+}
+
+// Available returns the amount of nodes that can be taken from the pool
+// without allocating.
+func (p *Pool[T]) Available() int {
+	return p.available
+}
+
+// Get takes a node from the pool, allocating a new slab if none are
+// available.
+func (p *Pool[T]) get(val T) *node[T] {
+	var n *node[T]
+
+	if p.free != nil {
+		n = p.free
+		p.free = n.next
+		n.next = nil
+
+	} else {
+		if len(p.slab) == 0 {
+			p.slab = make([]node[T], p.slabSize)
+			p.available += p.slabSize
+		}
+		n = &p.slab[0]
+		p.slab = p.slab[1:]
+	}
+
+	p.available--
+	n.val = val
+	return n
+}
+
+// Put returns a node to the pool. The value is cleared so the pool doesn't
+// keep it alive.
+func (p *Pool[T]) put(n *node[T]) {
+	var zero T
+
+	n.val = zero
+	n.prev = nil
+	n.next = p.free
+	p.free = n
+	p.available++
+}
@@ -0,0 +1,256 @@
+package linkedlist
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
+)
+
+func TestNewPool(t *testing.T) {
+	t.Parallel()
+
+	p := NewPool[int](4)
+	assert.Eq(t, p.Available(), 0)
+
+	l := NewPooled(p)
+	l.InsertLast(1)
+	assert.Eq(t, p.Available(), 3)
+
+	l.InsertAllAt(1, 2, 3, 4, 5)
+	assert.Eq(t, p.Available(), 3)
+	assert.True(t, slices.Equal(l.ToSlice(), []int{1, 2, 3, 4, 5}))
+}
+
+func TestFailedNewPool(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	NewPool[int](0)
+}
+
+func TestPoolReuse(t *testing.T) {
+	t.Parallel()
+
+	p := NewPool[string](8)
+	l := NewPooled(p)
+
+	l.InsertLast("a")
+	l.InsertLast("b")
+	l.InsertFirst("c")
+	assert.Eq(t, p.Available(), 5)
+
+	l.RemoveFirst()
+	l.RemoveLast()
+	assert.Eq(t, p.Available(), 7)
+
+	// Returned nodes don't keep their values alive.
+	for n := p.free; n != nil; n = n.next {
+		assert.Eq(t, n.val, "")
+		assert.True(t, n.prev == nil)
+	}
+
+	for i := 0; i < 1_000; i++ {
+		l.InsertFirst("d")
+		l.RemoveLast()
+	}
+	assert.Eq(t, p.Available(), 7)
+	assert.Eq(t, l.Count(), 1)
+	assert.Eq(t, l.First(), "d")
+}
+
+func TestPoolRemoval(t *testing.T) {
+	t.Parallel()
+
+	p := NewPool[int](16)
+	l := NewPooled(p)
+	l.InsertAllAt(0, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	assert.Eq(t, p.Available(), 6)
+
+	l.Remove(5)
+	assert.True(t, l.RemoveValue(2))
+	assert.Eq(t, l.RemoveAll(func(val int) bool { return val%3 == 0 }), 4)
+	assert.Eq(t, p.Available(), 12)
+	assert.True(t, slices.Equal(l.ToSlice(), []int{1, 4, 7, 8}))
+
+	assert.True(t, slices.Equal(l.Drain(), []int{1, 4, 7, 8}))
+	assert.Eq(t, p.Available(), 16)
+}
+
+func TestPoolSharing(t *testing.T) {
+	t.Parallel()
+
+	p := NewPool[int](4)
+	a := NewPooled(p)
+	b := NewPooled(p)
+
+	a.InsertAllAt(0, 1, 2, 3)
+	b.InsertAllAt(0, 4, 5, 6)
+	assert.Eq(t, p.Available(), 2)
+
+	// Splicing moves the nodes, so none are returned to the pool.
+	a.Splice(&b, 1)
+	assert.Eq(t, p.Available(), 2)
+	assert.True(t, b.Empty())
+	assert.True(t, slices.Equal(a.ToSlice(), []int{1, 4, 5, 6, 2, 3}))
+
+	// The tail of a split keeps using the pool.
+	tail := a.Split(4)
+	tail.Clear()
+	assert.Eq(t, p.Available(), 4)
+	assert.True(t, slices.Equal(a.ToSlice(), []int{1, 4, 5, 6}))
+
+	c := a.Clone()
+	assert.Eq(t, p.Available(), 0)
+	assert.True(t, c.Equal(a))
+
+	c.Clear()
+	a.Clear()
+	assert.Eq(t, p.Available(), 8)
+}
+
+func TestPoolSplice(t *testing.T) {
+	t.Parallel()
+
+	p := NewPool[int](8)
+	q := NewPool[int](8)
+
+	a := NewPooled(p)
+	a.InsertAllAt(0, 1, 5)
+	b := NewPooled(p)
+	b.InsertAllAt(0, 2, 3, 4)
+
+	// Lists sharing a pool splice without copying.
+	a.Splice(&b, 1)
+	assert.Eq(t, p.Available(), 3)
+	assert.True(t, slices.Equal(a.ToSlice(), []int{1, 2, 3, 4, 5}))
+
+	// Lists using different pools copy the values into nodes from the
+	// receiving pool, returning the others to their own pool.
+	c := NewPooled(q)
+	c.InsertAllAt(0, 6, 7)
+	a.Splice(&c, a.Count())
+	assert.True(t, c.Empty())
+	assert.Eq(t, q.Available(), 8)
+	assert.Eq(t, p.Available(), 1)
+	assert.True(t, slices.Equal(a.ToSlice(), []int{1, 2, 3, 4, 5, 6, 7}))
+	assert.True(t, slices.Equal(backwards(a), []int{1, 2, 3, 4, 5, 6, 7}))
+
+	d := New[int]()
+	d.InsertLast(0)
+	a.Splice(&d, 0)
+	assert.Eq(t, p.Available(), 0)
+
+	a.Clear()
+	assert.Eq(t, p.Available(), 8)
+	assert.Eq(t, q.Available(), 8)
+}
+
+func TestFailedPoolMoveFirst(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	a := NewPooled(NewPool[int](8))
+	b := NewPooled(NewPool[int](8))
+	a.InsertLast(1)
+	a.MoveFirst(&b)
+}
+
+func TestPoolRandomised(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+	p := NewPool[int](32)
+	pooled := NewPooled(p)
+	plain := New[int]()
+
+	for i := 0; i < 10_000; i++ {
+		switch r.Intn(6) {
+		case 0:
+			pooled.InsertFirst(i)
+			plain.InsertFirst(i)
+		case 1:
+			pooled.InsertLast(i)
+			plain.InsertLast(i)
+		case 2:
+			index := r.Intn(plain.Count() + 1)
+			pooled.Insert(i, index)
+			plain.Insert(i, index)
+		case 3:
+			if !plain.Empty() {
+				pooled.RemoveFirst()
+				plain.RemoveFirst()
+			}
+		case 4:
+			if !plain.Empty() {
+				pooled.RemoveLast()
+				plain.RemoveLast()
+			}
+		case 5:
+			if !plain.Empty() {
+				index := r.Intn(plain.Count())
+				pooled.Remove(index)
+				plain.Remove(index)
+			}
+		}
+	}
+
+	assert.True(t, pooled.Equal(plain))
+	assert.True(t, slices.Equal(backwards(pooled), backwards(plain)))
+
+	pooled.Clear()
+	assert.Eq(t, p.Available()%32, 0)
+}
+
+func BenchmarkPooledLinkedListInsertAndRemove(b *testing.B) {
+	l := NewPooled(NewPool[int](64))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		l.InsertLast(x)
+		l.RemoveLast()
+	}
+}
+
+func BenchmarkLinkedListChurn(b *testing.B) {
+	l := New[int]()
+	for x := 0; x < 1_000; x++ {
+		l.InsertLast(x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		l.InsertFirst(x)
+		l.RemoveLast()
+	}
+}
+
+func BenchmarkPooledLinkedListChurn(b *testing.B) {
+	l := NewPooled(NewPool[int](64))
+	for x := 0; x < 1_000; x++ {
+		l.InsertLast(x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		l.InsertFirst(x)
+		l.RemoveLast()
+	}
+}
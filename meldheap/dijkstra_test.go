@@ -0,0 +1,167 @@
+package meldheap
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"github.com/nomad-software/goad/binaryheap"
+	"golang.org/x/exp/slices"
+)
+
+// The benchmarks in this file compare the heaps against binaryheap when
+// finding shortest paths across a large sparse graph. The binary heap has no
+// decrease-key so it inserts duplicate entries and skips stale ones, while the
+// mergeable heaps update their entries in place.
+
+type edge struct {
+	to     int
+	weight int
+}
+
+type entry struct {
+	dist   int
+	vertex int
+}
+
+func closer(a, b entry) bool { return a.dist < b.dist }
+
+// Sparse returns a random graph with the passed amount of vertices, each with
+// the passed amount of outgoing edges.
+func sparse(vertices int, degree int) [][]edge {
+	r := rand.New(rand.NewSource(1))
+	graph := make([][]edge, vertices)
+
+	for v := range graph {
+		graph[v] = make([]edge, degree)
+		for i := range graph[v] {
+			graph[v][i] = edge{to: r.Intn(vertices), weight: 1 + r.Intn(100)}
+		}
+	}
+
+	return graph
+}
+
+// Distances returns a slice of distances initialised to infinity.
+func distances(vertices int) []int {
+	dist := make([]int, vertices)
+	for i := range dist {
+		dist[i] = math.MaxInt
+	}
+	dist[0] = 0
+	return dist
+}
+
+func dijkstraBinary(graph [][]edge) []int {
+	dist := distances(len(graph))
+	h := binaryheap.New(closer)
+	h.Insert(entry{dist: 0, vertex: 0})
+
+	for !h.Empty() {
+		e := h.Extract()
+		if e.dist > dist[e.vertex] {
+			continue
+		}
+
+		for _, ed := range graph[e.vertex] {
+			if d := e.dist + ed.weight; d < dist[ed.to] {
+				dist[ed.to] = d
+				h.Insert(entry{dist: d, vertex: ed.to})
+			}
+		}
+	}
+
+	return dist
+}
+
+func dijkstraPairing(graph [][]edge) []int {
+	dist := distances(len(graph))
+	nodes := make([]*PairingNode[entry], len(graph))
+	h := NewPairing(closer)
+	h.Insert(entry{dist: 0, vertex: 0})
+
+	for !h.Empty() {
+		e := h.Extract()
+
+		for _, ed := range graph[e.vertex] {
+			if d := e.dist + ed.weight; d < dist[ed.to] {
+				dist[ed.to] = d
+				if nodes[ed.to] == nil {
+					nodes[ed.to] = h.Insert(entry{dist: d, vertex: ed.to})
+				} else {
+					h.DecreaseKey(nodes[ed.to], entry{dist: d, vertex: ed.to})
+				}
+			}
+		}
+	}
+
+	return dist
+}
+
+func dijkstraFibonacci(graph [][]edge) []int {
+	dist := distances(len(graph))
+	nodes := make([]*FibonacciNode[entry], len(graph))
+	h := NewFibonacci(closer)
+	h.Insert(entry{dist: 0, vertex: 0})
+
+	for !h.Empty() {
+		e := h.Extract()
+
+		for _, ed := range graph[e.vertex] {
+			if d := e.dist + ed.weight; d < dist[ed.to] {
+				dist[ed.to] = d
+				if nodes[ed.to] == nil {
+					nodes[ed.to] = h.Insert(entry{dist: d, vertex: ed.to})
+				} else {
+					h.DecreaseKey(nodes[ed.to], entry{dist: d, vertex: ed.to})
+				}
+			}
+		}
+	}
+
+	return dist
+}
+
+func TestDijkstra(t *testing.T) {
+	t.Parallel()
+
+	graph := sparse(10_000, 8)
+	expected := dijkstraBinary(graph)
+
+	assert.True(t, slices.Equal(dijkstraPairing(graph), expected))
+	assert.True(t, slices.Equal(dijkstraFibonacci(graph), expected))
+}
+
+func BenchmarkDijkstraBinaryHeap(b *testing.B) {
+	graph := sparse(100_000, 8)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		dijkstraBinary(graph)
+	}
+}
+
+func BenchmarkDijkstraPairingHeap(b *testing.B) {
+	graph := sparse(100_000, 8)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		dijkstraPairing(graph)
+	}
+}
+
+func BenchmarkDijkstraFibonacciHeap(b *testing.B) {
+	graph := sparse(100_000, 8)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		dijkstraFibonacci(graph)
+	}
+}
@@ -0,0 +1,340 @@
+package meldheap
+
+import (
+	"github.com/nomad-software/goad/internal/bag"
+	"golang.org/x/exp/slices"
+)
+
+// FibonacciNode is the node type used within the Fibonacci heap.
+// It is returned on insertion as a handle to the value, for use with
+// DecreaseKey.
+type FibonacciNode[T comparable] struct {
+	val    T
+	parent *FibonacciNode[T]
+	child  *FibonacciNode[T]
+	left   *FibonacciNode[T]
+	right  *FibonacciNode[T]
+	degree int
+	marked bool
+}
+
+// Value returns the value held by the node.
+func (n *FibonacciNode[T]) Value() T {
+	return n.val
+}
+
+// FibonacciHeap is the main Fibonacci heap type.
+// Insertion and melding take constant time, decreasing a key takes amortised
+// constant time and extraction takes amortised logarithmic time. Copies made
+// by assignment see the same list of roots, so extracting through one
+// consolidates trees the other still points into. Use Clone to copy the nodes.
+type FibonacciHeap[T comparable] struct {
+	top   *FibonacciNode[T]
+	count int
+	pred  func(a T, b T) bool
+}
+
+// NewFibonacci is used to create a new Fibonacci heap.
+// The passed function is a predicate that returns true if the first parameter
+// is greater than the second. This predicate defines the sorting order between
+// the heap items and is called during insertion and extraction.
+func NewFibonacci[T comparable](pred func(a T, b T) bool) FibonacciHeap[T] {
+	return FibonacciHeap[T]{
+		pred: pred,
+	}
+}
+
+// Count returns the amount of entries in the heap.
+func (h FibonacciHeap[T]) Count() int {
+	return h.count
+}
+
+// Empty returns true if the heap is empty, false if not.
+func (h FibonacciHeap[T]) Empty() bool {
+	return h.Count() == 0
+}
+
+// Splice joins two circular lists together, returning either. Either list may
+// be nil.
+func splice[T comparable](a *FibonacciNode[T], b *FibonacciNode[T]) *FibonacciNode[T] {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	aRight := a.right
+	bLeft := b.left
+
+	a.right = b
+	b.left = a
+	aRight.left = bLeft
+	bLeft.right = aRight
+
+	return a
+}
+
+// Unlink removes the passed node from its circular list, leaving it in a list
+// of its own.
+func unlink[T comparable](n *FibonacciNode[T]) {
+	n.left.right = n.right
+	n.right.left = n.left
+	n.left = n
+	n.right = n
+}
+
+// AddRoot adds the passed node to the root list, updating the top of the heap
+// if needed.
+func (h *FibonacciHeap[T]) addRoot(n *FibonacciNode[T]) {
+	n.parent = nil
+	n.marked = false
+	splice(h.top, n)
+
+	if h.top == nil || h.pred(n.val, h.top.val) {
+		h.top = n
+	}
+}
+
+// Insert inserts a new value into the heap, returning a handle to it.
+func (h *FibonacciHeap[T]) Insert(val T) *FibonacciNode[T] {
+	n := &FibonacciNode[T]{val: val}
+	n.left = n
+	n.right = n
+
+	h.addRoot(n)
+	h.count++
+
+	return n
+}
+
+// Peek returns the first value at the top of the heap.
+func (h FibonacciHeap[T]) Peek() T {
+	if h.top == nil {
+		panic("fibonacci heap empty, peeking failed")
+	}
+	return h.top.val
+}
+
+// Extract returns and removes the first value from the heap.
+func (h *FibonacciHeap[T]) Extract() T {
+	if h.top == nil {
+		panic("fibonacci heap empty, extracting failed")
+	}
+
+	top := h.top
+	for top.child != nil {
+		c := top.child
+		if c.right == c {
+			top.child = nil
+		} else {
+			top.child = c.right
+		}
+		unlink(c)
+		c.parent = nil
+		splice(top, c)
+	}
+
+	if top.right == top {
+		h.top = nil
+	} else {
+		h.top = top.right
+		unlink(top)
+		h.consolidate()
+	}
+
+	h.count--
+	return top.val
+}
+
+// Consolidate links roots of equal degree until every root has a distinct
+// degree, then finds the new top of the heap.
+func (h *FibonacciHeap[T]) consolidate() {
+	// The degree of any node is bounded by the logarithm of the heap size to
+	// the base of the golden ratio, which is well within this for any heap
+	// that fits in memory.
+	var degrees [96]*FibonacciNode[T]
+	maxDegree := 0
+
+	for h.top != nil {
+		x := h.top
+		if x.right == x {
+			h.top = nil
+		} else {
+			h.top = x.right
+			unlink(x)
+		}
+
+		d := x.degree
+		for degrees[d] != nil {
+			y := degrees[d]
+			if h.pred(y.val, x.val) {
+				x, y = y, x
+			}
+
+			y.parent = x
+			y.marked = false
+			x.child = splice(x.child, y)
+			x.degree++
+
+			degrees[d] = nil
+			d++
+		}
+
+		degrees[d] = x
+		maxDegree = max(maxDegree, d)
+	}
+
+	for _, n := range degrees[:maxDegree+1] {
+		if n != nil {
+			h.addRoot(n)
+		}
+	}
+}
+
+// DecreaseKey replaces the value held by the passed node, moving it towards
+// the top of the heap. The new value must not be less than the old value
+// according to the predicate. The node must belong to this heap.
+func (h *FibonacciHeap[T]) DecreaseKey(n *FibonacciNode[T], val T) {
+	if h.pred(n.val, val) {
+		panic("new value is less than the old value, decreasing key failed")
+	}
+
+	n.val = val
+	parent := n.parent
+
+	if parent != nil && h.pred(n.val, parent.val) {
+		h.cut(n)
+
+		// Cascade up through marked ancestors, cutting each one.
+		for parent.parent != nil {
+			if !parent.marked {
+				parent.marked = true
+				break
+			}
+			grandparent := parent.parent
+			h.cut(parent)
+			parent = grandparent
+		}
+	}
+
+	if h.pred(n.val, h.top.val) {
+		h.top = n
+	}
+}
+
+// Cut removes the passed node from its parent's children and moves it to the
+// root list.
+func (h *FibonacciHeap[T]) cut(n *FibonacciNode[T]) {
+	parent := n.parent
+
+	if parent.child == n {
+		if n.right == n {
+			parent.child = nil
+		} else {
+			parent.child = n.right
+		}
+	}
+
+	unlink(n)
+	parent.degree--
+	h.addRoot(n)
+}
+
+// Meld moves every value from the passed heap into this one in constant time,
+// leaving the passed heap empty. Handles into the passed heap remain valid and
+// now belong to this heap. This heap's predicate is used from then on.
+func (h *FibonacciHeap[T]) Meld(other *FibonacciHeap[T]) {
+	if other.top != nil {
+		splice(h.top, other.top)
+
+		if h.top == nil || h.pred(other.top.val, h.top.val) {
+			h.top = other.top
+		}
+	}
+
+	h.count += other.count
+	other.Clear()
+}
+
+// Walk calls the passed function for every node in the heap in no particular
+// order.
+func (h FibonacciHeap[T]) walk(f func(n *FibonacciNode[T])) {
+	if h.top == nil {
+		return
+	}
+
+	stack := []*FibonacciNode[T]{h.top}
+	for len(stack) > 0 {
+		first := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for n := first; ; {
+			f(n)
+			if n.child != nil {
+				stack = append(stack, n.child)
+			}
+			n = n.right
+			if n == first {
+				break
+			}
+		}
+	}
+}
+
+// Contains returns true if the value exists in the heap, false if not.
+func (h FibonacciHeap[T]) Contains(val T) bool {
+	var result bool
+	h.walk(func(n *FibonacciNode[T]) {
+		result = result || n.val == val
+	})
+	return result
+}
+
+// Clear empties the entire heap.
+func (h *FibonacciHeap[T]) Clear() {
+	h.top = nil
+	h.count = 0
+}
+
+// ToSlice returns the values of the heap in the order they would be extracted.
+func (h FibonacciHeap[T]) ToSlice() []T {
+	vals := h.values()
+	slices.SortFunc(vals, h.pred)
+	return vals
+}
+
+// Values returns the values within the heap in no particular order.
+func (h FibonacciHeap[T]) values() []T {
+	vals := make([]T, 0, h.count)
+	h.walk(func(n *FibonacciNode[T]) {
+		vals = append(vals, n.val)
+	})
+	return vals
+}
+
+// ForEach iterates over the dataset within the heap in the order the values
+// would be extracted, calling the passed function for each value.
+func (h FibonacciHeap[T]) ForEach(f func(val T)) {
+	for _, v := range h.ToSlice() {
+		f(v)
+	}
+}
+
+// Clone returns a copy of the heap that shares no nodes with the original.
+// The copy uses the same predicate as the original. Handles into the original
+// heap are not valid for the copy.
+func (h FibonacciHeap[T]) Clone() FibonacciHeap[T] {
+	c := NewFibonacci(h.pred)
+	h.walk(func(n *FibonacciNode[T]) {
+		c.Insert(n.val)
+	})
+	return c
+}
+
+// Equal returns true if both heaps contain the same values the same amount of
+// times, false if not. The structure of each heap is ignored.
+func (h FibonacciHeap[T]) Equal(other FibonacciHeap[T]) bool {
+	return bag.Equal(h.values(), other.values())
+}
@@ -0,0 +1,264 @@
+package meldheap
+
+import (
+	"github.com/nomad-software/goad/internal/bag"
+	"golang.org/x/exp/slices"
+)
+
+// PairingNode is the node type used within the pairing heap.
+// It is returned on insertion as a handle to the value, for use with
+// DecreaseKey.
+type PairingNode[T comparable] struct {
+	val   T
+	child *PairingNode[T]
+	next  *PairingNode[T]
+	prev  *PairingNode[T] // Parent if this is the leftmost child.
+}
+
+// Value returns the value held by the node.
+func (n *PairingNode[T]) Value() T {
+	return n.val
+}
+
+// PairingHeap is the main pairing heap type.
+// Insertion and melding take constant time, extraction takes amortised
+// logarithmic time. Assigning a pairing heap only copies its root and count,
+// so both copies share the tree of nodes and melding or extracting through one
+// corrupts the other. Use Clone to copy the nodes.
+type PairingHeap[T comparable] struct {
+	root  *PairingNode[T]
+	count int
+	pred  func(a T, b T) bool
+}
+
+// NewPairing is used to create a new pairing heap.
+// The passed function is a predicate that returns true if the first parameter
+// is greater than the second. This predicate defines the sorting order between
+// the heap items and is called during insertion and extraction.
+func NewPairing[T comparable](pred func(a T, b T) bool) PairingHeap[T] {
+	return PairingHeap[T]{
+		pred: pred,
+	}
+}
+
+// Count returns the amount of entries in the heap.
+func (h PairingHeap[T]) Count() int {
+	return h.count
+}
+
+// Empty returns true if the heap is empty, false if not.
+func (h PairingHeap[T]) Empty() bool {
+	return h.Count() == 0
+}
+
+// Link makes the root with the lesser value the leftmost child of the other,
+// returning the new root. Either root may be nil.
+func (h *PairingHeap[T]) link(a *PairingNode[T], b *PairingNode[T]) *PairingNode[T] {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	if h.pred(b.val, a.val) {
+		a, b = b, a
+	}
+
+	b.prev = a
+	b.next = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+
+	return a
+}
+
+// MergePairs links a list of siblings into a single tree using the two pass
+// method, returning its root.
+func (h *PairingHeap[T]) mergePairs(first *PairingNode[T]) *PairingNode[T] {
+	if first == nil {
+		return nil
+	}
+
+	// Link siblings in pairs from left to right, stacking the results.
+	var pairs *PairingNode[T]
+	for first != nil {
+		a := first
+		b := a.next
+
+		if b == nil {
+			a.prev = nil
+			a.next = pairs
+			pairs = a
+			break
+		}
+
+		first = b.next
+		a.prev, a.next, b.prev, b.next = nil, nil, nil, nil
+
+		n := h.link(a, b)
+		n.next = pairs
+		pairs = n
+	}
+
+	// Link the stacked pairs from right to left.
+	root := pairs
+	pairs = pairs.next
+	root.next = nil
+
+	for pairs != nil {
+		n := pairs
+		pairs = n.next
+		n.next = nil
+		root = h.link(root, n)
+	}
+
+	return root
+}
+
+// Insert inserts a new value into the heap, returning a handle to it.
+func (h *PairingHeap[T]) Insert(val T) *PairingNode[T] {
+	n := &PairingNode[T]{val: val}
+	h.root = h.link(h.root, n)
+	h.count++
+	return n
+}
+
+// Peek returns the first value at the top of the heap.
+func (h PairingHeap[T]) Peek() T {
+	if h.root == nil {
+		panic("pairing heap empty, peeking failed")
+	}
+	return h.root.val
+}
+
+// Extract returns and removes the first value from the heap.
+func (h *PairingHeap[T]) Extract() T {
+	if h.root == nil {
+		panic("pairing heap empty, extracting failed")
+	}
+
+	val := h.root.val
+	h.root = h.mergePairs(h.root.child)
+	h.count--
+
+	return val
+}
+
+// DecreaseKey replaces the value held by the passed node, moving it towards
+// the top of the heap. The new value must not be less than the old value
+// according to the predicate. The node must belong to this heap.
+func (h *PairingHeap[T]) DecreaseKey(n *PairingNode[T], val T) {
+	if h.pred(n.val, val) {
+		panic("new value is less than the old value, decreasing key failed")
+	}
+
+	n.val = val
+
+	if n == h.root {
+		return
+	}
+
+	if n.prev.child == n {
+		n.prev.child = n.next
+	} else {
+		n.prev.next = n.next
+	}
+
+	if n.next != nil {
+		n.next.prev = n.prev
+	}
+
+	n.prev = nil
+	n.next = nil
+	h.root = h.link(h.root, n)
+}
+
+// Meld moves every value from the passed heap into this one in constant time,
+// leaving the passed heap empty. Handles into the passed heap remain valid and
+// now belong to this heap. This heap's predicate is used from then on.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	h.root = h.link(h.root, other.root)
+	h.count += other.count
+	other.Clear()
+}
+
+// Walk calls the passed function for every node in the heap in no particular
+// order.
+func (h PairingHeap[T]) walk(f func(n *PairingNode[T])) {
+	if h.root == nil {
+		return
+	}
+
+	stack := []*PairingNode[T]{h.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for ; n != nil; n = n.next {
+			f(n)
+			if n.child != nil {
+				stack = append(stack, n.child)
+			}
+		}
+	}
+}
+
+// Contains returns true if the value exists in the heap, false if not.
+func (h PairingHeap[T]) Contains(val T) bool {
+	var result bool
+	h.walk(func(n *PairingNode[T]) {
+		result = result || n.val == val
+	})
+	return result
+}
+
+// Clear empties the entire heap.
+func (h *PairingHeap[T]) Clear() {
+	h.root = nil
+	h.count = 0
+}
+
+// ToSlice returns the values of the heap in the order they would be extracted.
+func (h PairingHeap[T]) ToSlice() []T {
+	vals := h.values()
+	slices.SortFunc(vals, h.pred)
+	return vals
+}
+
+// Values returns the values within the heap in no particular order.
+func (h PairingHeap[T]) values() []T {
+	vals := make([]T, 0, h.count)
+	h.walk(func(n *PairingNode[T]) {
+		vals = append(vals, n.val)
+	})
+	return vals
+}
+
+// ForEach iterates over the dataset within the heap in the order the values
+// would be extracted, calling the passed function for each value.
+func (h PairingHeap[T]) ForEach(f func(val T)) {
+	for _, v := range h.ToSlice() {
+		f(v)
+	}
+}
+
+// Clone returns a copy of the heap that shares no nodes with the original.
+// The copy uses the same predicate as the original. Handles into the original
+// heap are not valid for the copy.
+func (h PairingHeap[T]) Clone() PairingHeap[T] {
+	c := NewPairing(h.pred)
+	h.walk(func(n *PairingNode[T]) {
+		c.Insert(n.val)
+	})
+	return c
+}
+
+// Equal returns true if both heaps contain the same values the same amount of
+// times, false if not. The structure of each heap is ignored.
+func (h PairingHeap[T]) Equal(other PairingHeap[T]) bool {
+	return bag.Equal(h.values(), other.values())
+}
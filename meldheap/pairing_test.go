@@ -0,0 +1,300 @@
+package meldheap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
+)
+
+func TestNewPairing(t *testing.T) {
+	t.Parallel()
+
+	h := NewPairing(func(a, b int) bool { return a < b })
+	assert.True(t, h.Empty())
+
+	for _, v := range []int{6, 5, 2, 9, 4, 8, 7, 1, 3, 10} {
+		h.Insert(v)
+	}
+
+	assert.False(t, h.Empty())
+	assert.Eq(t, h.Count(), 10)
+
+	for i := 1; i <= 10; i++ {
+		assert.Eq(t, h.Peek(), i)
+		assert.Eq(t, h.Extract(), i)
+	}
+
+	assert.True(t, h.Empty())
+	assert.Eq(t, h.Count(), 0)
+}
+
+func TestPairingStruct(t *testing.T) {
+	t.Parallel()
+
+	type Foo struct {
+		Foo int
+		Bar string
+	}
+
+	h := NewPairing(func(a, b Foo) bool { return a.Foo > b.Foo })
+
+	f1 := Foo{Foo: 2, Bar: "bar"}
+	f2 := Foo{Foo: 4, Bar: "qux"}
+	f3 := Foo{Foo: 3, Bar: "baz"}
+	f4 := Foo{Foo: 1, Bar: "foo"}
+
+	h.Insert(f1)
+	h.Insert(f2)
+	n := h.Insert(f3)
+	h.Insert(f4)
+
+	assert.Eq(t, n.Value(), f3)
+	assert.True(t, h.Contains(f1))
+	assert.False(t, h.Contains(Foo{Foo: 5}))
+
+	assert.Eq(t, h.Extract(), f2)
+	assert.Eq(t, h.Extract(), f3)
+	assert.Eq(t, h.Extract(), f1)
+	assert.Eq(t, h.Extract(), f4)
+}
+
+func TestFailedPairingExtract(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	h := NewPairing(func(a, b int) bool { return a < b })
+	h.Extract()
+}
+
+func TestPairingDecreaseKey(t *testing.T) {
+	t.Parallel()
+
+	h := NewPairing(func(a, b int) bool { return a < b })
+
+	nodes := make([]*PairingNode[int], 0)
+	for i := 10; i < 20; i++ {
+		nodes = append(nodes, h.Insert(i))
+	}
+
+	// Extract once so the remaining values are arranged into trees.
+	assert.Eq(t, h.Extract(), 10)
+
+	h.DecreaseKey(nodes[9], 5)
+	assert.Eq(t, h.Peek(), 5)
+	assert.Eq(t, nodes[9].Value(), 5)
+
+	h.DecreaseKey(nodes[5], 15)
+	h.DecreaseKey(nodes[4], 7)
+
+	for _, v := range []int{5, 7, 11, 12, 13, 15, 16, 17, 18} {
+		assert.Eq(t, h.Extract(), v)
+	}
+	assert.True(t, h.Empty())
+}
+
+func TestFailedPairingDecreaseKey(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	h := NewPairing(func(a, b int) bool { return a < b })
+	n := h.Insert(1)
+	h.DecreaseKey(n, 2)
+}
+
+func TestPairingMeld(t *testing.T) {
+	t.Parallel()
+
+	a := NewPairing(func(a, b int) bool { return a < b })
+	b := NewPairing(func(a, b int) bool { return a < b })
+
+	for i := 0; i < 10; i += 2 {
+		a.Insert(i)
+	}
+
+	var n *PairingNode[int]
+	for i := 1; i < 10; i += 2 {
+		n = b.Insert(i)
+	}
+
+	a.Meld(&b)
+	assert.Eq(t, a.Count(), 10)
+	assert.True(t, b.Empty())
+
+	a.DecreaseKey(n, -1)
+	assert.Eq(t, a.Extract(), -1)
+
+	for _, v := range []int{0, 1, 2, 3, 4, 5, 6, 7, 8} {
+		assert.Eq(t, a.Extract(), v)
+	}
+
+	a.Meld(&b)
+	assert.True(t, a.Empty())
+
+	b.Insert(1)
+	a.Meld(&b)
+	assert.Eq(t, a.Peek(), 1)
+}
+
+func TestPairingRandomised(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		key int
+		id  int
+	}
+
+	r := rand.New(rand.NewSource(1))
+	h := NewPairing(func(a, b item) bool { return a.key < b.key || (a.key == b.key && a.id < b.id) })
+	nodes := make([]*PairingNode[item], 0)
+	expected := make(map[int]*PairingNode[item])
+
+	for i := 0; i < 20_000; i++ {
+		switch r.Intn(4) {
+		case 0, 1:
+			n := h.Insert(item{key: r.Intn(10_000), id: len(nodes)})
+			expected[len(nodes)] = n
+			nodes = append(nodes, n)
+
+		case 2:
+			if len(nodes) > 0 {
+				n := nodes[r.Intn(len(nodes))]
+				if _, ok := expected[n.Value().id]; ok {
+					h.DecreaseKey(n, item{key: n.Value().key - r.Intn(1_000), id: n.Value().id})
+				}
+			}
+
+		case 3:
+			if !h.Empty() {
+				top := h.Extract()
+				if i%50 == 0 {
+					for _, n := range expected {
+						assert.True(t, top.key <= n.Value().key)
+					}
+				}
+				assert.Eq(t, expected[top.id].Value(), top)
+				delete(expected, top.id)
+			}
+		}
+
+		assert.Eq(t, h.Count(), len(expected))
+	}
+
+	keys := make([]int, 0, len(expected))
+	for _, n := range expected {
+		keys = append(keys, n.Value().key)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		assert.Eq(t, h.Extract().key, k)
+	}
+	assert.True(t, h.Empty())
+}
+
+func TestPairingClearing(t *testing.T) {
+	t.Parallel()
+
+	h := NewPairing(func(a, b int) bool { return a < b })
+	h.Insert(1)
+	h.Insert(2)
+
+	h.Clear()
+	assert.True(t, h.Empty())
+	assert.False(t, h.Contains(1))
+
+	h.Insert(3)
+	assert.Eq(t, h.Peek(), 3)
+}
+
+func TestPairingForEach(t *testing.T) {
+	t.Parallel()
+
+	h := NewPairing(func(a, b int) bool { return a < b })
+	for _, v := range []int{5, 2, 4, 1, 3} {
+		h.Insert(v)
+	}
+	h.Extract()
+
+	i := 2
+	h.ForEach(func(val int) {
+		assert.Eq(t, val, i)
+		i++
+	})
+	assert.Eq(t, i, 6)
+	assert.Eq(t, h.Count(), 4)
+}
+
+func TestPairingClone(t *testing.T) {
+	t.Parallel()
+
+	h := NewPairing(func(a, b int) bool { return a < b })
+	h.Insert(1)
+	h.Insert(2)
+	h.Insert(3)
+
+	c := h.Clone()
+	assert.True(t, c.Equal(h))
+
+	c.Extract()
+	h.Insert(4)
+
+	assert.False(t, c.Equal(h))
+	assert.Eq(t, h.Count(), 4)
+	assert.Eq(t, c.Count(), 2)
+	assert.Eq(t, h.Peek(), 1)
+	assert.Eq(t, c.Peek(), 2)
+}
+
+func TestPairingEqual(t *testing.T) {
+	t.Parallel()
+
+	a := NewPairing(func(a, b int) bool { return a < b })
+	b := NewPairing(func(a, b int) bool { return a < b })
+	assert.True(t, a.Equal(b))
+
+	a.Insert(1)
+	a.Insert(2)
+	b.Insert(2)
+	assert.False(t, a.Equal(b))
+
+	b.Insert(1)
+	assert.True(t, a.Equal(b))
+}
+
+func BenchmarkPairingInsert(b *testing.B) {
+	h := NewPairing(func(a, b int) bool { return a < b })
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		h.Insert(x)
+	}
+}
+
+func BenchmarkPairingInsertAndExtract(b *testing.B) {
+	h := NewPairing(func(a, b int) bool { return a < b })
+
+	for x := 0; x < 1_000_000; x++ {
+		h.Insert(x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		h.Insert(h.Extract() + 1_000_000)
+	}
+}
@@ -0,0 +1,262 @@
+package minmaxheap
+
+import (
+	"math/bits"
+
+	"github.com/nomad-software/goad/internal/bag"
+	"golang.org/x/exp/slices"
+)
+
+// MinMaxHeap is the main heap type.
+// Values on even levels of the tree are less than all of their descendants and
+// values on odd levels are greater, which keeps both the smallest and largest
+// values near the root. As with a binary heap, a copy made by assignment keeps
+// working on the slice of the original, so inserting or extracting through
+// either copy disturbs the other. Use Clone for an independent heap.
+type MinMaxHeap[T comparable] struct {
+	data []T
+	pred func(a T, b T) bool
+}
+
+// New is used to create a new heap.
+// The passed function is a predicate that returns true if the first parameter
+// is less than the second. This predicate defines the sorting order between
+// the heap items and is called during insertion and extraction. The least item
+// according to the predicate is the minimum and the greatest is the maximum.
+func New[T comparable](pred func(a T, b T) bool) MinMaxHeap[T] {
+	return MinMaxHeap[T]{
+		data: make([]T, 0, 16),
+		pred: pred,
+	}
+}
+
+// FromSlice is used to create a new heap from the passed values.
+// The heap is built bottom up in linear time. See New for a description of the
+// predicate.
+func FromSlice[T comparable](vals []T, pred func(a T, b T) bool) MinMaxHeap[T] {
+	h := MinMaxHeap[T]{
+		data: slices.Clone(vals),
+		pred: pred,
+	}
+
+	for i := h.Count()/2 - 1; i >= 0; i-- {
+		h.pushDown(i)
+	}
+
+	return h
+}
+
+// Count returns the amount of entries in the heap.
+func (h MinMaxHeap[T]) Count() int {
+	return len(h.data)
+}
+
+// Empty returns true if the heap is empty, false if not.
+func (h MinMaxHeap[T]) Empty() bool {
+	return h.Count() == 0
+}
+
+// IsMinLevel returns true if the passed index lies on a min level.
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// Before returns true if the value at index a belongs above the value at index
+// b on the passed type of level.
+func (h MinMaxHeap[T]) before(a int, b int, min bool) bool {
+	if min {
+		return h.pred(h.data[a], h.data[b])
+	}
+	return h.pred(h.data[b], h.data[a])
+}
+
+// Swap exchanges the values at the passed indexes.
+func (h *MinMaxHeap[T]) swap(a int, b int) {
+	h.data[a], h.data[b] = h.data[b], h.data[a]
+}
+
+// PushUp moves the value at the passed index up through the heap until it
+// finds its correct position.
+func (h *MinMaxHeap[T]) pushUp(i int) {
+	if i == 0 {
+		return
+	}
+
+	min := isMinLevel(i)
+	parent := (i - 1) / 2
+
+	if h.before(parent, i, min) {
+		h.swap(i, parent)
+		h.pushUpLevels(parent, !min)
+	} else {
+		h.pushUpLevels(i, min)
+	}
+}
+
+// PushUpLevels moves the value at the passed index up through its grandparents
+// while it belongs above them.
+func (h *MinMaxHeap[T]) pushUpLevels(i int, min bool) {
+	for i > 2 {
+		grandparent := ((i-1)/2 - 1) / 2
+		if !h.before(i, grandparent, min) {
+			return
+		}
+		h.swap(i, grandparent)
+		i = grandparent
+	}
+}
+
+// PushDown moves the value at the passed index down through the heap until it
+// finds its correct position.
+func (h *MinMaxHeap[T]) pushDown(i int) {
+	min := isMinLevel(i)
+
+	for {
+		first := 2*i + 1
+		if first >= h.Count() {
+			return
+		}
+
+		// Find the best of the children and grandchildren.
+		m := first
+		if first+1 < h.Count() && h.before(first+1, m, min) {
+			m = first + 1
+		}
+		for c := 2*first + 1; c <= 2*first+4 && c < h.Count(); c++ {
+			if h.before(c, m, min) {
+				m = c
+			}
+		}
+
+		if !h.before(m, i, min) {
+			return
+		}
+
+		h.swap(m, i)
+
+		if m <= first+1 {
+			return
+		}
+
+		parent := (m - 1) / 2
+		if h.before(parent, m, min) {
+			h.swap(m, parent)
+		}
+
+		i = m
+	}
+}
+
+// Insert inserts a new value into the heap.
+func (h *MinMaxHeap[T]) Insert(val T) {
+	h.data = append(h.data, val)
+	h.pushUp(h.Count() - 1)
+}
+
+// MaxIndex returns the index of the largest value.
+func (h MinMaxHeap[T]) maxIndex() int {
+	switch h.Count() {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+
+	if h.pred(h.data[1], h.data[2]) {
+		return 2
+	}
+	return 1
+}
+
+// PeekMin returns the smallest value in the heap.
+func (h MinMaxHeap[T]) PeekMin() T {
+	if h.Empty() {
+		panic("min-max heap empty, peeking failed")
+	}
+	return h.data[0]
+}
+
+// PeekMax returns the largest value in the heap.
+func (h MinMaxHeap[T]) PeekMax() T {
+	if h.Empty() {
+		panic("min-max heap empty, peeking failed")
+	}
+	return h.data[h.maxIndex()]
+}
+
+// ExtractMin returns and removes the smallest value from the heap.
+func (h *MinMaxHeap[T]) ExtractMin() T {
+	if h.Empty() {
+		panic("min-max heap empty, extracting failed")
+	}
+	return h.remove(0)
+}
+
+// ExtractMax returns and removes the largest value from the heap.
+func (h *MinMaxHeap[T]) ExtractMax() T {
+	if h.Empty() {
+		panic("min-max heap empty, extracting failed")
+	}
+	return h.remove(h.maxIndex())
+}
+
+// Remove removes the value at the passed index, replacing it with the last
+// value in the heap.
+func (h *MinMaxHeap[T]) remove(i int) T {
+	val := h.data[i]
+	last := h.Count() - 1
+
+	h.data[i] = h.data[last]
+	h.data = h.data[0:last]
+
+	if i < last {
+		h.pushDown(i)
+	}
+
+	return val
+}
+
+// Contains returns true if the value exists in the heap, false if not.
+func (h MinMaxHeap[T]) Contains(val T) bool {
+	for _, v := range h.data {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+// Clear empties the entire heap.
+func (h *MinMaxHeap[T]) Clear() {
+	h.data = h.data[:0:0]
+}
+
+// ToSlice returns the values of the heap from smallest to largest.
+func (h MinMaxHeap[T]) ToSlice() []T {
+	vals := slices.Clone(h.data)
+	slices.SortFunc(vals, h.pred)
+	return vals
+}
+
+// ForEach iterates over the dataset within the heap from smallest to largest,
+// calling the passed function for each value.
+func (h MinMaxHeap[T]) ForEach(f func(val T)) {
+	for _, v := range h.ToSlice() {
+		f(v)
+	}
+}
+
+// Clone returns a copy of the heap that shares no storage with the original.
+// The copy uses the same predicate as the original.
+func (h MinMaxHeap[T]) Clone() MinMaxHeap[T] {
+	return MinMaxHeap[T]{
+		data: slices.Clone(h.data),
+		pred: h.pred,
+	}
+}
+
+// Equal returns true if both heaps contain the same values the same amount of
+// times, false if not. The order of the values within each heap is ignored.
+func (h MinMaxHeap[T]) Equal(other MinMaxHeap[T]) bool {
+	return bag.Equal(h.data, other.data)
+}
@@ -0,0 +1,249 @@
+package minmaxheap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
+)
+
+func ascending(a, b int) bool { return a < b }
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	h := New(ascending)
+	assert.True(t, h.Empty())
+
+	for _, v := range []int{6, 5, 2, 9, 4, 8, 7, 1, 3, 10} {
+		h.Insert(v)
+	}
+
+	assert.False(t, h.Empty())
+	assert.Eq(t, h.Count(), 10)
+	assert.Eq(t, h.PeekMin(), 1)
+	assert.Eq(t, h.PeekMax(), 10)
+
+	for i := 1; i <= 5; i++ {
+		assert.Eq(t, h.ExtractMin(), i)
+		assert.Eq(t, h.ExtractMax(), 11-i)
+	}
+
+	assert.True(t, h.Empty())
+}
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+
+	type Foo struct {
+		Foo int
+		Bar string
+	}
+
+	h := New(func(a, b Foo) bool { return a.Foo < b.Foo })
+
+	f1 := Foo{Foo: 2, Bar: "bar"}
+	f2 := Foo{Foo: 4, Bar: "qux"}
+	f3 := Foo{Foo: 3, Bar: "baz"}
+	f4 := Foo{Foo: 1, Bar: "foo"}
+
+	h.Insert(f1)
+	h.Insert(f2)
+	h.Insert(f3)
+	h.Insert(f4)
+
+	assert.True(t, h.Contains(f3))
+	assert.Eq(t, h.ExtractMax(), f2)
+	assert.Eq(t, h.ExtractMin(), f4)
+	assert.Eq(t, h.ExtractMax(), f3)
+	assert.Eq(t, h.ExtractMin(), f1)
+	assert.False(t, h.Contains(f3))
+}
+
+func TestSmallHeaps(t *testing.T) {
+	t.Parallel()
+
+	h := New(ascending)
+	h.Insert(1)
+	assert.Eq(t, h.PeekMin(), 1)
+	assert.Eq(t, h.PeekMax(), 1)
+
+	h.Insert(2)
+	assert.Eq(t, h.PeekMin(), 1)
+	assert.Eq(t, h.PeekMax(), 2)
+
+	assert.Eq(t, h.ExtractMax(), 2)
+	assert.Eq(t, h.ExtractMax(), 1)
+	assert.True(t, h.Empty())
+}
+
+func TestFailedExtract(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	h := New(ascending)
+	h.ExtractMax()
+}
+
+func TestFailedPeek(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	h := New(ascending)
+	h.PeekMin()
+}
+
+func TestRandomised(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+	h := New(ascending)
+	expected := make([]int, 0)
+
+	for i := 0; i < 20_000; i++ {
+		switch {
+		case r.Intn(3) > 0 || len(expected) == 0:
+			v := r.Intn(1_000)
+			h.Insert(v)
+			expected = append(expected, v)
+			slices.Sort(expected)
+
+		case r.Intn(2) == 0:
+			assert.Eq(t, h.ExtractMin(), expected[0])
+			expected = expected[1:]
+
+		default:
+			assert.Eq(t, h.ExtractMax(), expected[len(expected)-1])
+			expected = expected[:len(expected)-1]
+		}
+
+		assert.Eq(t, h.Count(), len(expected))
+		if len(expected) > 0 {
+			assert.Eq(t, h.PeekMin(), expected[0])
+			assert.Eq(t, h.PeekMax(), expected[len(expected)-1])
+		}
+	}
+}
+
+func TestSlices(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 100; n++ {
+		vals := make([]int, n)
+		for i := range vals {
+			vals[i] = r.Intn(50)
+		}
+
+		h := FromSlice(vals, ascending)
+		sorted := slices.Clone(vals)
+		slices.Sort(sorted)
+
+		assert.True(t, slices.Equal(h.ToSlice(), sorted))
+
+		for len(sorted) > 0 {
+			assert.Eq(t, h.ExtractMax(), sorted[len(sorted)-1])
+			sorted = sorted[:len(sorted)-1]
+			if len(sorted) > 0 {
+				assert.Eq(t, h.ExtractMin(), sorted[0])
+				sorted = sorted[1:]
+			}
+		}
+	}
+}
+
+func TestClearing(t *testing.T) {
+	t.Parallel()
+
+	h := FromSlice([]int{3, 1, 2}, ascending)
+	h.Clear()
+	assert.True(t, h.Empty())
+
+	h.Insert(1)
+	assert.Eq(t, h.Count(), 1)
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	h := FromSlice([]int{5, 2, 4, 1, 3}, ascending)
+
+	i := 1
+	h.ForEach(func(val int) {
+		assert.Eq(t, val, i)
+		i++
+	})
+	assert.Eq(t, h.Count(), 5)
+	assert.Eq(t, h.ExtractMin(), 1)
+	assert.Eq(t, h.ExtractMax(), 5)
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	h := FromSlice([]int{1, 2, 3}, ascending)
+
+	c := h.Clone()
+	assert.True(t, c.Equal(h))
+
+	c.ExtractMin()
+	h.Insert(4)
+
+	assert.False(t, c.Equal(h))
+	assert.Eq(t, h.PeekMin(), 1)
+	assert.Eq(t, c.PeekMax(), 3)
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := FromSlice([]int{1, 2, 2}, ascending)
+	b := FromSlice([]int{2, 1, 2}, ascending)
+	assert.True(t, a.Equal(b))
+
+	b.ExtractMax()
+	b.Insert(1)
+	assert.False(t, a.Equal(b))
+}
+
+func BenchmarkMinMaxHeapInsert(b *testing.B) {
+	h := New(ascending)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		h.Insert(x)
+	}
+}
+
+func BenchmarkMinMaxHeapExtract(b *testing.B) {
+	h := New(ascending)
+
+	for x := 0; x < 1_000_000; x++ {
+		h.Insert(x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		if x%2 == 0 {
+			h.Insert(h.ExtractMin())
+		} else {
+			h.Insert(h.ExtractMax())
+		}
+	}
+}
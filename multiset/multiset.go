@@ -0,0 +1,263 @@
+package multiset
+
+import (
+	"github.com/nomad-software/goad/binaryheap"
+	"github.com/nomad-software/goad/hashmap"
+)
+
+// Entry is a value of a multiset along with the amount of times it occurs.
+type Entry[T comparable] struct {
+	Val   T
+	Count int
+}
+
+// Multiset is the main multiset type, also known as a bag or counter.
+// A multiset is like a set, but holds every value any amount of times, making
+// it suitable for counting things such as words or histogram bins. Only the
+// amount of times a value occurs is stored, never the copies themselves.
+// Copying a multiset by assignment shares the counts of its values but not its
+// totals, so once either copy changes the two disagree and Total and Distinct
+// no longer match the counts. Always use Clone to copy a multiset.
+type Multiset[T comparable] struct {
+	data  hashmap.HashMap[T, int]
+	total int
+}
+
+// New is used to create a new multiset.
+func New[T comparable]() Multiset[T] {
+	return Multiset[T]{
+		data: hashmap.New[T, int](),
+	}
+}
+
+// FromSlice is used to create a new multiset containing the passed values,
+// adding each value once for every time it occurs in the slice.
+func FromSlice[T comparable](vals []T) Multiset[T] {
+	m := New[T]()
+	m.AddAll(vals...)
+	return m
+}
+
+// FromMap is used to create a new multiset from the passed map of values to
+// the amount of times they occur. Values with an amount of zero or less are
+// ignored.
+func FromMap[T comparable](vals map[T]int) Multiset[T] {
+	m := New[T]()
+	m.data.Grow(len(vals))
+	for v, n := range vals {
+		if n > 0 {
+			m.Add(v, n)
+		}
+	}
+	return m
+}
+
+// Distinct returns the amount of distinct values in the multiset.
+func (m Multiset[T]) Distinct() int {
+	return m.data.Count()
+}
+
+// Total returns the amount of values in the multiset, counting every
+// occurrence.
+func (m Multiset[T]) Total() int {
+	return m.total
+}
+
+// Empty returns true if the multiset is empty, false if not.
+func (m Multiset[T]) Empty() bool {
+	return m.total == 0
+}
+
+// Add adds the passed value to the multiset n times.
+func (m *Multiset[T]) Add(val T, n int) {
+	if n < 0 {
+		panic("amount is negative, adding failed")
+	}
+
+	if n == 0 {
+		return
+	}
+
+	m.data.Merge(val, n, func(old int, n int) int {
+		return old + n
+	})
+	m.total += n
+}
+
+// AddAll adds each of the passed values to the multiset once.
+func (m *Multiset[T]) AddAll(vals ...T) {
+	for _, v := range vals {
+		m.Add(v, 1)
+	}
+}
+
+// Remove removes the passed value from the multiset up to n times, returning
+// the amount of times it was removed. The value is removed entirely once it no
+// longer occurs.
+func (m *Multiset[T]) Remove(val T, n int) int {
+	if n < 0 {
+		panic("amount is negative, removing failed")
+	}
+
+	var removed int
+	m.data.Compute(val, func(old int, ok bool) (int, bool) {
+		if !ok {
+			return old, false
+		}
+		removed = min(old, n)
+		return old - removed, old > removed
+	})
+
+	m.total -= removed
+	return removed
+}
+
+// RemoveAll removes every occurrence of the passed value from the multiset,
+// returning the amount of times it was removed.
+func (m *Multiset[T]) RemoveAll(val T) int {
+	n, _ := m.data.Remove(val)
+	m.total -= n
+	return n
+}
+
+// CountOf returns the amount of times the passed value occurs in the multiset.
+func (m Multiset[T]) CountOf(val T) int {
+	n, _ := m.data.Get(val)
+	return n
+}
+
+// Contains returns true if the value occurs in the multiset at least once,
+// false if not.
+func (m Multiset[T]) Contains(val T) bool {
+	return m.data.ContainsKey(val)
+}
+
+// MostCommon returns up to k of the most common values along with the amount
+// of times they occur, from most to least common. Values that occur the same
+// amount of times are returned in no particular order.
+func (m Multiset[T]) MostCommon(k int) []Entry[T] {
+	if k < 1 {
+		panic("amount must be greater than zero, getting most common failed")
+	}
+
+	if m.Empty() {
+		return []Entry[T]{}
+	}
+
+	top := binaryheap.NewTopK(min(k, m.Distinct()), func(a Entry[T], b Entry[T]) bool {
+		return a.Count > b.Count
+	})
+	m.ForEach(func(val T, n int) {
+		top.Insert(Entry[T]{Val: val, Count: n})
+	})
+
+	return top.ToSlice()
+}
+
+// Clear empties the entire multiset.
+func (m *Multiset[T]) Clear() {
+	m.data.Clear()
+	m.total = 0
+}
+
+// ForEach iterates over the distinct values within the multiset, calling the
+// passed function for each value along with the amount of times it occurs.
+func (m Multiset[T]) ForEach(f func(val T, count int)) {
+	m.data.ForEach(f)
+}
+
+// Elements iterates over every occurrence of every value within the multiset,
+// calling the passed function once for each occurrence. Occurrences of the
+// same value are passed one after the other.
+func (m Multiset[T]) Elements(f func(val T)) {
+	m.data.ForEach(func(val T, n int) {
+		for i := 0; i < n; i++ {
+			f(val)
+		}
+	})
+}
+
+// ToSlice returns every occurrence of every value within the multiset, with
+// occurrences of the same value next to each other.
+func (m Multiset[T]) ToSlice() []T {
+	vals := make([]T, 0, m.Total())
+	m.Elements(func(val T) {
+		vals = append(vals, val)
+	})
+	return vals
+}
+
+// ToMap returns a map of the values of the multiset to the amount of times
+// they occur.
+func (m Multiset[T]) ToMap() map[T]int {
+	vals := make(map[T]int, m.Distinct())
+	m.ForEach(func(val T, n int) {
+		vals[val] = n
+	})
+	return vals
+}
+
+// Sum returns a new multiset containing the values of both multisets, with
+// each value occurring the amount of times it occurs in both added together.
+func (m Multiset[T]) Sum(other Multiset[T]) Multiset[T] {
+	c := m.Clone()
+	other.ForEach(func(val T, n int) {
+		c.Add(val, n)
+	})
+	return c
+}
+
+// Difference returns a new multiset containing the values of this multiset,
+// with each value occurring the amount of times it occurs in this multiset
+// less the amount of times it occurs in the other. Values that no longer occur
+// are left out.
+func (m Multiset[T]) Difference(other Multiset[T]) Multiset[T] {
+	c := m.Clone()
+	other.ForEach(func(val T, n int) {
+		c.Remove(val, n)
+	})
+	return c
+}
+
+// Intersection returns a new multiset containing the values found in both
+// multisets, with each value occurring the least amount of times it occurs in
+// either.
+func (m Multiset[T]) Intersection(other Multiset[T]) Multiset[T] {
+	if other.Distinct() < m.Distinct() {
+		m, other = other, m
+	}
+
+	c := New[T]()
+	m.ForEach(func(val T, n int) {
+		c.Add(val, min(n, other.CountOf(val)))
+	})
+	return c
+}
+
+// Union returns a new multiset containing the values found in either
+// multiset, with each value occurring the greatest amount of times it occurs
+// in either.
+func (m Multiset[T]) Union(other Multiset[T]) Multiset[T] {
+	c := m.Clone()
+	other.ForEach(func(val T, n int) {
+		if old := c.CountOf(val); n > old {
+			c.Add(val, n-old)
+		}
+	})
+	return c
+}
+
+// Clone returns a copy of the multiset that shares no storage with the
+// original.
+func (m Multiset[T]) Clone() Multiset[T] {
+	return Multiset[T]{
+		data:  m.data.Clone(),
+		total: m.total,
+	}
+}
+
+// Equal returns true if both multisets contain the same values occurring the
+// same amount of times, false if not.
+func (m Multiset[T]) Equal(other Multiset[T]) bool {
+	return m.total == other.total && m.data.Equal(other.data)
+}
@@ -0,0 +1,321 @@
+package multiset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	m := New[string]()
+	assert.True(t, m.Empty())
+
+	m.Add("foo", 3)
+	m.Add("bar", 1)
+	m.Add("foo", 2)
+	m.Add("baz", 0)
+
+	assert.False(t, m.Empty())
+	assert.Eq(t, m.Distinct(), 2)
+	assert.Eq(t, m.Total(), 6)
+	assert.Eq(t, m.CountOf("foo"), 5)
+	assert.Eq(t, m.CountOf("bar"), 1)
+	assert.Eq(t, m.CountOf("baz"), 0)
+	assert.True(t, m.Contains("foo"))
+	assert.False(t, m.Contains("baz"))
+}
+
+func TestFromSlice(t *testing.T) {
+	t.Parallel()
+
+	m := FromSlice(strings.Fields("the cat sat on the mat the end"))
+	assert.Eq(t, m.Distinct(), 6)
+	assert.Eq(t, m.Total(), 8)
+	assert.Eq(t, m.CountOf("the"), 3)
+	assert.Eq(t, m.CountOf("cat"), 1)
+}
+
+func TestFromMap(t *testing.T) {
+	t.Parallel()
+
+	m := FromMap(map[int]int{1: 2, 2: 0, 3: -1, 4: 4})
+	assert.Eq(t, m.Distinct(), 2)
+	assert.Eq(t, m.Total(), 6)
+	assert.Eq(t, m.CountOf(4), 4)
+	assert.False(t, m.Contains(2))
+	assert.False(t, m.Contains(3))
+}
+
+func TestFailedAdd(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	m := New[int]()
+	m.Add(1, -1)
+}
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	m := New[string]()
+	m.Add("foo", 5)
+	m.Add("bar", 2)
+
+	assert.Eq(t, m.Remove("foo", 3), 3)
+	assert.Eq(t, m.CountOf("foo"), 2)
+	assert.Eq(t, m.Total(), 4)
+
+	// Removing more than occur removes the value entirely.
+	assert.Eq(t, m.Remove("foo", 10), 2)
+	assert.False(t, m.Contains("foo"))
+	assert.Eq(t, m.Distinct(), 1)
+	assert.Eq(t, m.Total(), 2)
+
+	assert.Eq(t, m.Remove("foo", 1), 0)
+	assert.Eq(t, m.Remove("bar", 0), 0)
+	assert.Eq(t, m.CountOf("bar"), 2)
+
+	assert.Eq(t, m.RemoveAll("bar"), 2)
+	assert.Eq(t, m.RemoveAll("bar"), 0)
+	assert.True(t, m.Empty())
+}
+
+func TestFailedRemove(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	m := New[int]()
+	m.Add(1, 1)
+	m.Remove(1, -1)
+}
+
+func TestMostCommon(t *testing.T) {
+	t.Parallel()
+
+	m := New[string]()
+	m.Add("a", 1)
+	m.Add("b", 5)
+	m.Add("c", 3)
+	m.Add("d", 4)
+
+	top := m.MostCommon(2)
+	assert.True(t, slices.Equal(top, []Entry[string]{{"b", 5}, {"d", 4}}))
+
+	top = m.MostCommon(10)
+	assert.True(t, slices.Equal(top, []Entry[string]{{"b", 5}, {"d", 4}, {"c", 3}, {"a", 1}}))
+
+	m.Clear()
+	assert.Eq(t, len(m.MostCommon(1)), 0)
+}
+
+func TestFailedMostCommon(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	m := FromSlice([]int{1, 2, 3})
+	m.MostCommon(0)
+}
+
+func TestClear(t *testing.T) {
+	t.Parallel()
+
+	m := FromSlice([]int{1, 1, 2})
+	m.Clear()
+	assert.True(t, m.Empty())
+	assert.Eq(t, m.Distinct(), 0)
+	assert.Eq(t, m.Total(), 0)
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	m := FromSlice([]int{1, 2, 2, 3, 3, 3})
+
+	var distinct, total int
+	m.ForEach(func(val int, n int) {
+		assert.Eq(t, val, n)
+		distinct++
+		total += n
+	})
+	assert.Eq(t, distinct, 3)
+	assert.Eq(t, total, 6)
+}
+
+func TestElements(t *testing.T) {
+	t.Parallel()
+
+	m := FromSlice([]int{3, 1, 2, 3, 2, 3})
+
+	var sum int
+	m.Elements(func(val int) {
+		sum += val
+	})
+	assert.Eq(t, sum, 14)
+
+	vals := m.ToSlice()
+	slices.Sort(vals)
+	assert.True(t, slices.Equal(vals, []int{1, 2, 2, 3, 3, 3}))
+
+	// Occurrences of the same value are next to each other.
+	vals = m.ToSlice()
+	for i := 1; i < len(vals)-1; i++ {
+		if vals[i-1] == vals[i+1] {
+			assert.Eq(t, vals[i], vals[i-1])
+		}
+	}
+}
+
+func TestToMap(t *testing.T) {
+	t.Parallel()
+
+	m := FromSlice([]string{"foo", "bar", "foo"})
+	vals := m.ToMap()
+	assert.Eq(t, len(vals), 2)
+	assert.Eq(t, vals["foo"], 2)
+	assert.Eq(t, vals["bar"], 1)
+}
+
+func TestSum(t *testing.T) {
+	t.Parallel()
+
+	a := FromMap(map[string]int{"a": 3, "b": 1})
+	b := FromMap(map[string]int{"b": 2, "c": 1})
+
+	c := a.Sum(b)
+	assert.True(t, c.Equal(FromMap(map[string]int{"a": 3, "b": 3, "c": 1})))
+	assert.Eq(t, c.Total(), 7)
+	assert.Eq(t, a.Total(), 4)
+}
+
+func TestDifference(t *testing.T) {
+	t.Parallel()
+
+	a := FromMap(map[string]int{"a": 3, "b": 1, "c": 2})
+	b := FromMap(map[string]int{"a": 1, "b": 2, "d": 5})
+
+	c := a.Difference(b)
+	assert.True(t, c.Equal(FromMap(map[string]int{"a": 2, "c": 2})))
+	assert.Eq(t, c.Total(), 4)
+	assert.Eq(t, a.Total(), 6)
+
+	c = b.Difference(a)
+	assert.True(t, c.Equal(FromMap(map[string]int{"b": 1, "d": 5})))
+}
+
+func TestIntersection(t *testing.T) {
+	t.Parallel()
+
+	a := FromMap(map[string]int{"a": 3, "b": 1, "c": 2})
+	b := FromMap(map[string]int{"a": 1, "b": 2, "d": 5})
+
+	c := a.Intersection(b)
+	assert.True(t, c.Equal(FromMap(map[string]int{"a": 1, "b": 1})))
+	assert.Eq(t, c.Total(), 2)
+	assert.True(t, b.Intersection(a).Equal(c))
+	assert.True(t, a.Intersection(New[string]()).Empty())
+}
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+
+	a := FromMap(map[string]int{"a": 3, "b": 1, "c": 2})
+	b := FromMap(map[string]int{"a": 1, "b": 2, "d": 5})
+
+	c := a.Union(b)
+	assert.True(t, c.Equal(FromMap(map[string]int{"a": 3, "b": 2, "c": 2, "d": 5})))
+	assert.Eq(t, c.Total(), 12)
+	assert.True(t, b.Union(a).Equal(c))
+	assert.Eq(t, a.Total(), 6)
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	a := FromSlice([]int{1, 2, 2})
+	b := a.Clone()
+	assert.True(t, a.Equal(b))
+
+	b.Add(3, 1)
+	a.Remove(2, 1)
+	assert.False(t, a.Equal(b))
+	assert.Eq(t, a.Total(), 2)
+	assert.Eq(t, b.Total(), 4)
+	assert.Eq(t, b.CountOf(2), 2)
+}
+
+func TestAssignment(t *testing.T) {
+	t.Parallel()
+
+	m := FromSlice([]int{1, 2})
+
+	// Assignment shares the counts but not the total, so the copies disagree.
+	a := m
+	a.Add(2, 3)
+	assert.Eq(t, m.CountOf(2), 4)
+	assert.Eq(t, m.Total(), 2)
+	assert.Eq(t, a.Total(), 5)
+
+	c := a.Clone()
+	c.Add(1, 1)
+	assert.Eq(t, a.CountOf(1), 1)
+	assert.Eq(t, a.Total(), 5)
+	assert.Eq(t, c.Total(), 6)
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := FromSlice([]int{1, 2, 2})
+	b := FromSlice([]int{2, 1, 2})
+	c := FromSlice([]int{1, 1, 2})
+	d := FromSlice([]int{1, 2})
+
+	assert.True(t, a.Equal(b))
+	assert.False(t, a.Equal(c))
+	assert.False(t, a.Equal(d))
+	assert.True(t, New[int]().Equal(New[int]()))
+}
+
+func BenchmarkMultisetAdd(b *testing.B) {
+	m := New[int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Add(x%1_000, 1)
+	}
+}
+
+func BenchmarkMultisetMostCommon(b *testing.B) {
+	m := New[int]()
+	for i := 0; i < 10_000; i++ {
+		m.Add(i%1_000, i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.MostCommon(10)
+	}
+}
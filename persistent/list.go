@@ -0,0 +1,84 @@
+package persistent
+
+// ListNode is the node type used within the list.
+// Nodes are never modified once created so they can be shared between lists.
+type listNode[T comparable] struct {
+	next *listNode[T]
+	val  T
+}
+
+// List is an immutable singly linked list. Every update returns a new list
+// that shares its tail with the original.
+type List[T comparable] struct {
+	head  *listNode[T]
+	count int
+}
+
+// NewList is used to create a new empty list.
+func NewList[T comparable]() List[T] {
+	return List[T]{}
+}
+
+// Count returns the amount of entries in the list.
+func (l List[T]) Count() int {
+	return l.count
+}
+
+// Empty returns true if the list is empty, false if not.
+func (l List[T]) Empty() bool {
+	return l.Count() == 0
+}
+
+// Prepend returns a new list with the value added to the beginning.
+func (l List[T]) Prepend(val T) List[T] {
+	return List[T]{
+		head:  &listNode[T]{val: val, next: l.head},
+		count: l.count + 1,
+	}
+}
+
+// First returns the value at the beginning of the list.
+func (l List[T]) First() T {
+	if l.head == nil {
+		panic("list empty, getting first failed")
+	}
+	return l.head.val
+}
+
+// Rest returns a new list containing every value except the first.
+func (l List[T]) Rest() List[T] {
+	if l.head == nil {
+		panic("list empty, getting rest failed")
+	}
+	return List[T]{
+		head:  l.head.next,
+		count: l.count - 1,
+	}
+}
+
+// Reverse returns a new list containing the values in reverse order.
+func (l List[T]) Reverse() List[T] {
+	result := NewList[T]()
+	for n := l.head; n != nil; n = n.next {
+		result = result.Prepend(n.val)
+	}
+	return result
+}
+
+// Contains returns true if the value exists in the list, false if not.
+func (l List[T]) Contains(val T) bool {
+	for n := l.head; n != nil; n = n.next {
+		if n.val == val {
+			return true
+		}
+	}
+	return false
+}
+
+// ForEach iterates over the dataset within the list, calling the passed
+// function for each value.
+func (l List[T]) ForEach(f func(val T)) {
+	for n := l.head; n != nil; n = n.next {
+		f(n.val)
+	}
+}
@@ -0,0 +1,79 @@
+package persistent
+
+import (
+	"testing"
+
+	"github.com/nomad-software/assert"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	l := NewList[int]()
+	assert.True(t, l.Empty())
+
+	a := l.Prepend(1)
+	b := a.Prepend(2)
+	c := a.Prepend(3)
+
+	assert.True(t, l.Empty())
+	assert.Eq(t, a.Count(), 1)
+	assert.Eq(t, b.Count(), 2)
+	assert.Eq(t, c.Count(), 2)
+	assert.Eq(t, b.First(), 2)
+	assert.Eq(t, c.First(), 3)
+	assert.Eq(t, b.Rest().First(), 1)
+	assert.Eq(t, c.Rest().First(), 1)
+	assert.True(t, b.Rest().head == c.Rest().head)
+
+	assert.True(t, b.Contains(1))
+	assert.False(t, b.Contains(3))
+	assert.True(t, b.Rest().Rest().Empty())
+}
+
+func TestListReverse(t *testing.T) {
+	t.Parallel()
+
+	l := NewList[int]()
+	for i := 1; i <= 5; i++ {
+		l = l.Prepend(i)
+	}
+
+	i := 5
+	l.ForEach(func(val int) {
+		assert.Eq(t, val, i)
+		i--
+	})
+
+	i = 1
+	l.Reverse().ForEach(func(val int) {
+		assert.Eq(t, val, i)
+		i++
+	})
+
+	assert.Eq(t, l.First(), 5)
+}
+
+func TestFailedListFirst(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	l := NewList[int]()
+	l.First()
+}
+
+func BenchmarkListPrepend(b *testing.B) {
+	l := NewList[int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		l = l.Prepend(x)
+	}
+}
@@ -0,0 +1,392 @@
+package persistent
+
+import (
+	"math/bits"
+
+	"github.com/nomad-software/goad/hash"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	mapBits = 5
+	mapMask = 1<<mapBits - 1
+)
+
+// MapEntry is a single slot within a map node, holding either a key and value
+// or a pointer to a child node.
+type mapEntry[K comparable, V comparable] struct {
+	node *mapNode[K, V]
+	hash uint32
+	key  K
+	val  V
+}
+
+// MapNode is the node type used within the hash array mapped trie.
+// Bitmap nodes store one entry for every set bit in the bitmap, collision
+// nodes store a flat list of entries that all share the same hash.
+type mapNode[K comparable, V comparable] struct {
+	owner     *owner
+	bitmap    uint32
+	entries   []mapEntry[K, V]
+	collision bool
+}
+
+// Editable returns a node that can be modified by the passed owner, copying
+// it if it belongs to anyone else.
+func (n *mapNode[K, V]) editable(o *owner) *mapNode[K, V] {
+	if o != nil && n.owner == o {
+		return n
+	}
+	return &mapNode[K, V]{
+		owner:     o,
+		bitmap:    n.bitmap,
+		entries:   slices.Clone(n.entries),
+		collision: n.collision,
+	}
+}
+
+// Index returns the bit and entry index for the passed hash at the passed
+// level of the trie.
+func (n *mapNode[K, V]) index(hash uint32, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & mapMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// Map is an immutable hash map stored as a hash array mapped trie. Every
+// update copies only the path to the changed entry and returns a new map.
+type Map[K comparable, V comparable] struct {
+	root  *mapNode[K, V]
+	count int
+}
+
+// NewMap is used to create a new empty map.
+func NewMap[K comparable, V comparable]() Map[K, V] {
+	return Map[K, V]{
+		root: &mapNode[K, V]{},
+	}
+}
+
+// Count returns the amount of entries in the map.
+func (m Map[K, V]) Count() int {
+	return m.count
+}
+
+// Empty returns true if the map is empty, false if not.
+func (m Map[K, V]) Empty() bool {
+	return m.Count() == 0
+}
+
+// Get gets a value from the map relating to the passed key.
+func (m Map[K, V]) Get(key K) (val V, ok bool) {
+	h := hash.Hash(key)
+	n := m.root
+
+	for shift := uint(0); n != nil; shift += mapBits {
+		if n.collision {
+			for _, e := range n.entries {
+				if e.key == key {
+					return e.val, true
+				}
+			}
+			return val, false
+		}
+
+		bit, i := n.index(h, shift)
+		if n.bitmap&bit == 0 {
+			return val, false
+		}
+
+		e := n.entries[i]
+		if e.node == nil {
+			if e.key == key {
+				return e.val, true
+			}
+			return val, false
+		}
+
+		n = e.node
+	}
+
+	return val, false
+}
+
+// ContainsKey returns true if the passed key is present, false if not.
+func (m Map[K, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Put returns a new map with the value added relating to the passed key.
+func (m Map[K, V]) Put(key K, val V) Map[K, V] {
+	m.put(key, val, nil)
+	return m
+}
+
+// Put adds the value relating to the passed key. Nodes belonging to the passed
+// owner are modified in place, all others are copied.
+func (m *Map[K, V]) put(key K, val V, o *owner) {
+	if m.root == nil {
+		m.root = &mapNode[K, V]{owner: o}
+	}
+
+	var added bool
+	m.root = put(m.root, 0, hash.Hash(key), key, val, o, &added)
+
+	if added {
+		m.count++
+	}
+}
+
+// Put recursively adds the entry to the passed subtree, returning the new
+// subtree root.
+func put[K comparable, V comparable](n *mapNode[K, V], shift uint, h uint32, key K, val V, o *owner, added *bool) *mapNode[K, V] {
+	if n.collision {
+		if h != n.entries[0].hash {
+			bit, _ := n.index(n.entries[0].hash, shift)
+			wrap := &mapNode[K, V]{
+				owner:   o,
+				bitmap:  bit,
+				entries: []mapEntry[K, V]{{node: n}},
+			}
+			return put(wrap, shift, h, key, val, o, added)
+		}
+
+		for i, e := range n.entries {
+			if e.key == key {
+				if e.val == val {
+					return n
+				}
+				result := n.editable(o)
+				result.entries[i].val = val
+				return result
+			}
+		}
+
+		result := n.editable(o)
+		result.entries = append(result.entries, mapEntry[K, V]{hash: h, key: key, val: val})
+		*added = true
+		return result
+	}
+
+	bit, i := n.index(h, shift)
+
+	if n.bitmap&bit == 0 {
+		result := n.editable(o)
+		result.entries = slices.Insert(result.entries, i, mapEntry[K, V]{hash: h, key: key, val: val})
+		result.bitmap |= bit
+		*added = true
+		return result
+	}
+
+	e := n.entries[i]
+
+	if e.node != nil {
+		child := put(e.node, shift+mapBits, h, key, val, o, added)
+		if child == e.node {
+			return n
+		}
+		result := n.editable(o)
+		result.entries[i].node = child
+		return result
+	}
+
+	if e.key == key {
+		if e.val == val {
+			return n
+		}
+		result := n.editable(o)
+		result.entries[i].val = val
+		return result
+	}
+
+	result := n.editable(o)
+	result.entries[i] = mapEntry[K, V]{
+		node: branch(shift+mapBits, e, mapEntry[K, V]{hash: h, key: key, val: val}, o),
+	}
+	*added = true
+	return result
+}
+
+// Branch returns a new subtree holding both passed entries.
+func branch[K comparable, V comparable](shift uint, a mapEntry[K, V], b mapEntry[K, V], o *owner) *mapNode[K, V] {
+	if a.hash == b.hash {
+		return &mapNode[K, V]{
+			owner:     o,
+			entries:   []mapEntry[K, V]{a, b},
+			collision: true,
+		}
+	}
+
+	ia := (a.hash >> shift) & mapMask
+	ib := (b.hash >> shift) & mapMask
+
+	if ia == ib {
+		return &mapNode[K, V]{
+			owner:   o,
+			bitmap:  1 << ia,
+			entries: []mapEntry[K, V]{{node: branch(shift+mapBits, a, b, o)}},
+		}
+	}
+
+	if ia > ib {
+		a, b = b, a
+	}
+
+	return &mapNode[K, V]{
+		owner:   o,
+		bitmap:  1<<ia | 1<<ib,
+		entries: []mapEntry[K, V]{a, b},
+	}
+}
+
+// Remove returns a new map without the value relating to the passed key.
+func (m Map[K, V]) Remove(key K) Map[K, V] {
+	m.remove(key, nil)
+	return m
+}
+
+// Remove deletes the value relating to the passed key. Nodes belonging to the
+// passed owner are modified in place, all others are copied.
+func (m *Map[K, V]) remove(key K, o *owner) {
+	if m.root == nil {
+		return
+	}
+
+	var removed bool
+	m.root = remove(m.root, 0, hash.Hash(key), key, o, &removed)
+
+	if m.root == nil {
+		m.root = &mapNode[K, V]{owner: o}
+	}
+
+	if removed {
+		m.count--
+	}
+}
+
+// Remove recursively deletes the entry from the passed subtree, returning the
+// new subtree root or nil if the subtree is now empty.
+func remove[K comparable, V comparable](n *mapNode[K, V], shift uint, h uint32, key K, o *owner, removed *bool) *mapNode[K, V] {
+	if n.collision {
+		for i, e := range n.entries {
+			if e.key == key {
+				*removed = true
+				if len(n.entries) == 1 {
+					return nil
+				}
+				result := n.editable(o)
+				result.entries = slices.Delete(result.entries, i, i+1)
+				return result
+			}
+		}
+		return n
+	}
+
+	bit, i := n.index(h, shift)
+	if n.bitmap&bit == 0 {
+		return n
+	}
+
+	e := n.entries[i]
+
+	if e.node != nil {
+		child := remove(e.node, shift+mapBits, h, key, o, removed)
+		if child == e.node {
+			return n
+		}
+
+		result := n.editable(o)
+
+		if child == nil {
+			result.entries = slices.Delete(result.entries, i, i+1)
+			result.bitmap &^= bit
+			if len(result.entries) == 0 {
+				return nil
+			}
+		} else if len(child.entries) == 1 && child.entries[0].node == nil {
+			result.entries[i] = child.entries[0]
+		} else {
+			result.entries[i].node = child
+		}
+
+		return result
+	}
+
+	if e.key != key {
+		return n
+	}
+
+	*removed = true
+
+	if len(n.entries) == 1 {
+		return nil
+	}
+
+	result := n.editable(o)
+	result.entries = slices.Delete(result.entries, i, i+1)
+	result.bitmap &^= bit
+	return result
+}
+
+// ForEach iterates over the dataset within the map, calling the passed
+// function for each value.
+func (m Map[K, V]) ForEach(f func(key K, val V)) {
+	if m.root != nil {
+		forEachEntry(m.root, f)
+	}
+}
+
+// ForEachEntry recursively walks the passed subtree.
+func forEachEntry[K comparable, V comparable](n *mapNode[K, V], f func(key K, val V)) {
+	for _, e := range n.entries {
+		if e.node != nil {
+			forEachEntry(e.node, f)
+		} else {
+			f(e.key, e.val)
+		}
+	}
+}
+
+// Builder returns a transient builder starting from the contents of this map.
+// The map itself is never modified.
+func (m Map[K, V]) Builder() *MapBuilder[K, V] {
+	return &MapBuilder[K, V]{
+		m:     m,
+		owner: &owner{},
+	}
+}
+
+// MapBuilder is a mutable builder used to construct a map quickly.
+// Nodes created by the builder are modified in place until the map is
+// returned, avoiding a copy for every update.
+type MapBuilder[K comparable, V comparable] struct {
+	m     Map[K, V]
+	owner *owner
+}
+
+// Count returns the amount of entries in the builder.
+func (b *MapBuilder[K, V]) Count() int {
+	return b.m.Count()
+}
+
+// Get gets a value from the builder relating to the passed key.
+func (b *MapBuilder[K, V]) Get(key K) (V, bool) {
+	return b.m.Get(key)
+}
+
+// Put adds a value to the builder relating to the passed key.
+func (b *MapBuilder[K, V]) Put(key K, val V) {
+	b.m.put(key, val, b.owner)
+}
+
+// Remove deletes a value from the builder relating to the passed key.
+func (b *MapBuilder[K, V]) Remove(key K) {
+	b.m.remove(key, b.owner)
+}
+
+// Map returns an immutable map of the builder's contents. The builder can
+// continue to be used afterwards without affecting the returned map.
+func (b *MapBuilder[K, V]) Map() Map[K, V] {
+	b.owner = &owner{}
+	return b.m
+}
@@ -0,0 +1,199 @@
+package persistent
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/nomad-software/assert"
+)
+
+type collider struct {
+	key int
+}
+
+func (c collider) Hash() uint32 {
+	return uint32(c.key % 3)
+}
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	m := NewMap[string, int]()
+	assert.True(t, m.Empty())
+
+	a := m.Put("foo", 1)
+	b := a.Put("bar", 2)
+	c := b.Put("foo", 3)
+	d := c.Remove("bar")
+
+	assert.True(t, m.Empty())
+	assert.Eq(t, a.Count(), 1)
+	assert.Eq(t, b.Count(), 2)
+	assert.Eq(t, c.Count(), 2)
+	assert.Eq(t, d.Count(), 1)
+
+	val, ok := b.Get("foo")
+	assert.True(t, ok)
+	assert.Eq(t, val, 1)
+
+	val, ok = c.Get("foo")
+	assert.True(t, ok)
+	assert.Eq(t, val, 3)
+
+	assert.True(t, c.ContainsKey("bar"))
+	assert.False(t, d.ContainsKey("bar"))
+
+	_, ok = d.Get("baz")
+	assert.False(t, ok)
+
+	assert.Eq(t, d.Remove("baz").Count(), 1)
+	assert.True(t, d.Remove("foo").Empty())
+}
+
+func TestMapCollisions(t *testing.T) {
+	t.Parallel()
+
+	m := NewMap[collider, int]()
+	for i := 0; i < 30; i++ {
+		m = m.Put(collider{key: i}, i)
+	}
+	assert.Eq(t, m.Count(), 30)
+
+	for i := 0; i < 30; i++ {
+		val, ok := m.Get(collider{key: i})
+		assert.True(t, ok)
+		assert.Eq(t, val, i)
+	}
+
+	for i := 0; i < 30; i += 2 {
+		m = m.Remove(collider{key: i})
+	}
+	assert.Eq(t, m.Count(), 15)
+
+	for i := 0; i < 30; i++ {
+		assert.Eq(t, m.ContainsKey(collider{key: i}), i%2 == 1)
+	}
+}
+
+func TestMapLargeCapacity(t *testing.T) {
+	t.Parallel()
+
+	limit := 100_000
+	m := NewMap[int, int]()
+	half := m
+
+	for i := 0; i < limit; i++ {
+		m = m.Put(i, i)
+		if i == limit/2 {
+			half = m
+		}
+	}
+
+	assert.Eq(t, m.Count(), limit)
+	assert.Eq(t, half.Count(), limit/2+1)
+
+	for i := 0; i < limit; i++ {
+		val, ok := m.Get(i)
+		assert.True(t, ok)
+		assert.Eq(t, val, i)
+		assert.Eq(t, half.ContainsKey(i), i <= limit/2)
+	}
+
+	for i := 0; i < limit; i++ {
+		m = m.Remove(i)
+		assert.Eq(t, m.Count(), limit-i-1)
+	}
+
+	assert.True(t, m.Empty())
+	assert.Eq(t, len(m.root.entries), 0)
+	assert.Eq(t, half.Count(), limit/2+1)
+}
+
+func TestMapForEach(t *testing.T) {
+	t.Parallel()
+
+	m := NewMap[string, int]()
+	for i := 0; i < 100; i++ {
+		m = m.Put(strconv.Itoa(i), i)
+	}
+
+	var count int
+	m.ForEach(func(key string, val int) {
+		assert.Eq(t, key, strconv.Itoa(val))
+		count++
+	})
+	assert.Eq(t, count, 100)
+}
+
+func TestMapBuilder(t *testing.T) {
+	t.Parallel()
+
+	base := NewMap[int, int]().Put(1, 1)
+
+	b := base.Builder()
+	for i := 0; i < 1_000; i++ {
+		b.Put(i, i*2)
+	}
+	b.Remove(500)
+	assert.Eq(t, b.Count(), 999)
+
+	m := b.Map()
+	b.Put(2_000, 1)
+	b.Put(2, 0)
+	b.Remove(3)
+
+	assert.Eq(t, base.Count(), 1)
+	val, _ := base.Get(1)
+	assert.Eq(t, val, 1)
+
+	assert.Eq(t, m.Count(), 999)
+	assert.False(t, m.ContainsKey(500))
+	assert.False(t, m.ContainsKey(2_000))
+	val, _ = m.Get(2)
+	assert.Eq(t, val, 4)
+	assert.True(t, m.ContainsKey(3))
+
+	val, ok := b.Get(2)
+	assert.True(t, ok)
+	assert.Eq(t, val, 0)
+	assert.Eq(t, b.Count(), 999)
+}
+
+func BenchmarkMapPut(b *testing.B) {
+	m := NewMap[int, int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m = m.Put(x, x)
+	}
+}
+
+func BenchmarkMapBuilderPut(b *testing.B) {
+	m := NewMap[int, int]().Builder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Put(x, x)
+	}
+}
+
+func BenchmarkMapGet(b *testing.B) {
+	m := NewMap[string, int]().Builder()
+
+	for x := 0; x < 1_000_000; x++ {
+		m.Put(strconv.Itoa(x), x)
+	}
+
+	s := m.Map()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		s.Get("500000")
+	}
+}
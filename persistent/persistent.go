@@ -0,0 +1,10 @@
+package persistent
+
+// Owner identifies the builder allowed to modify a node in place.
+// Nodes created by a builder are tagged with its owner, nodes without a
+// matching owner are shared with other versions and must be copied before
+// being modified. It is not zero sized so that every owner has a unique
+// address.
+type owner struct {
+	_ byte
+}
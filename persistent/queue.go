@@ -0,0 +1,139 @@
+package persistent
+
+// Queue is an immutable first in first out queue backed by a vector. Values
+// are enqueued by appending them to the vector and dequeued by moving an
+// offset past the head, so every operation takes effectively constant time no
+// matter how many versions share the vector. Dequeued values are kept until
+// the queue is emptied.
+type Queue[T comparable] struct {
+	data Vector[T]
+	head int
+}
+
+// NewQueue is used to create a new empty queue.
+func NewQueue[T comparable]() Queue[T] {
+	return Queue[T]{
+		data: NewVector[T](),
+	}
+}
+
+// Count returns the amount of entries in the queue.
+func (q Queue[T]) Count() int {
+	return q.data.Count() - q.head
+}
+
+// Empty returns true if the queue is empty, false if not.
+func (q Queue[T]) Empty() bool {
+	return q.Count() == 0
+}
+
+// Enqueue returns a new queue with the value added to the end.
+func (q Queue[T]) Enqueue(val T) Queue[T] {
+	return Queue[T]{data: q.data.Append(val), head: q.head}
+}
+
+// Peek returns the first value.
+func (q Queue[T]) Peek() T {
+	if q.Empty() {
+		panic("queue empty, peeking failed")
+	}
+	return q.data.Get(q.head)
+}
+
+// Dequeue returns the first value and a new queue without it.
+func (q Queue[T]) Dequeue() (T, Queue[T]) {
+	if q.Empty() {
+		panic("queue empty, dequeuing failed")
+	}
+
+	val := q.data.Get(q.head)
+
+	if q.Count() == 1 {
+		return val, NewQueue[T]()
+	}
+
+	return val, Queue[T]{data: q.data, head: q.head + 1}
+}
+
+// Contains returns true if the value exists in the queue, false if not.
+func (q Queue[T]) Contains(val T) bool {
+	var found bool
+	q.ForEach(func(v T) {
+		if v == val {
+			found = true
+		}
+	})
+	return found
+}
+
+// ForEach iterates over the dataset within the queue, calling the passed
+// function for each value.
+func (q Queue[T]) ForEach(f func(val T)) {
+	for i := q.head; i < q.data.Count(); {
+		leaf := q.data.leafFor(i)
+		for _, val := range leaf[i&vectorMask:] {
+			f(val)
+		}
+		i += len(leaf) - i&vectorMask
+	}
+}
+
+// Builder returns a transient builder starting from the contents of this
+// queue. The queue itself is never modified.
+func (q Queue[T]) Builder() *QueueBuilder[T] {
+	return &QueueBuilder[T]{
+		data: q.data.Builder(),
+		head: q.head,
+	}
+}
+
+// QueueBuilder is a mutable builder used to construct a queue quickly.
+type QueueBuilder[T comparable] struct {
+	data *VectorBuilder[T]
+	head int
+}
+
+// Count returns the amount of entries in the builder.
+func (b *QueueBuilder[T]) Count() int {
+	return b.data.Count() - b.head
+}
+
+// Peek returns the first value.
+func (b *QueueBuilder[T]) Peek() T {
+	if b.Count() == 0 {
+		panic("queue empty, peeking failed")
+	}
+	return b.data.Get(b.head)
+}
+
+// Enqueue adds a value to the end of the builder.
+func (b *QueueBuilder[T]) Enqueue(val T) {
+	b.data.Append(val)
+}
+
+// Dequeue removes and returns the first value.
+func (b *QueueBuilder[T]) Dequeue() T {
+	if b.Count() == 0 {
+		panic("queue empty, dequeuing failed")
+	}
+
+	val := b.data.Get(b.head)
+
+	if b.Count() == 1 {
+		b.data = NewVector[T]().Builder()
+		b.head = 0
+	} else {
+		b.head++
+	}
+
+	return val
+}
+
+// Queue returns an immutable queue of the builder's contents. The builder can
+// continue to be used afterwards without affecting the returned queue.
+func (b *QueueBuilder[T]) Queue() Queue[T] {
+	if b.Count() == 0 {
+		return NewQueue[T]()
+	}
+	return Queue[T]{data: b.data.Vector(), head: b.head}
+}
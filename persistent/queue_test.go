@@ -0,0 +1,188 @@
+package persistent
+
+import (
+	"testing"
+
+	"github.com/nomad-software/assert"
+)
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue[int]()
+	assert.True(t, q.Empty())
+
+	a := q.Enqueue(1).Enqueue(2).Enqueue(3)
+	assert.True(t, q.Empty())
+	assert.Eq(t, a.Count(), 3)
+	assert.Eq(t, a.Peek(), 1)
+	assert.True(t, a.Contains(3))
+	assert.False(t, a.Contains(4))
+
+	val, b := a.Dequeue()
+	assert.Eq(t, val, 1)
+	assert.Eq(t, b.Count(), 2)
+	assert.Eq(t, a.Count(), 3)
+
+	c := b.Enqueue(4)
+	d := b.Enqueue(5)
+
+	for _, expected := range []int{2, 3, 4} {
+		val, c = c.Dequeue()
+		assert.Eq(t, val, expected)
+	}
+	for _, expected := range []int{2, 3, 5} {
+		val, d = d.Dequeue()
+		assert.Eq(t, val, expected)
+	}
+
+	assert.True(t, c.Empty())
+	assert.True(t, d.Empty())
+	assert.Eq(t, a.Peek(), 1)
+}
+
+func TestQueueForEach(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue[int]()
+	for i := 1; i <= 5; i++ {
+		q = q.Enqueue(i)
+	}
+	_, q = q.Dequeue()
+	q = q.Enqueue(6)
+
+	i := 2
+	q.ForEach(func(val int) {
+		assert.Eq(t, val, i)
+		i++
+	})
+	assert.Eq(t, i, 7)
+}
+
+func TestQueueSharedDequeue(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue[int]()
+	for i := 0; i < 100; i++ {
+		q = q.Enqueue(i)
+	}
+
+	// Every version dequeues from the same shared vector.
+	for i := 0; i < 100; i++ {
+		val, r := q.Dequeue()
+		assert.Eq(t, val, 0)
+		assert.Eq(t, r.Peek(), 1)
+		assert.Eq(t, r.Count(), 99)
+	}
+
+	for i := 0; i < 100; i++ {
+		var val int
+		val, q = q.Dequeue()
+		assert.Eq(t, val, i)
+		assert.False(t, q.Contains(i))
+	}
+	assert.True(t, q.Empty())
+}
+
+func TestQueueBuilder(t *testing.T) {
+	t.Parallel()
+
+	base := NewQueue[int]().Enqueue(0).Enqueue(1)
+
+	b := base.Builder()
+	for i := 2; i < 1_000; i++ {
+		b.Enqueue(i)
+	}
+	assert.Eq(t, b.Dequeue(), 0)
+	assert.Eq(t, b.Peek(), 1)
+	assert.Eq(t, b.Count(), 999)
+
+	q := b.Queue()
+
+	b.Dequeue()
+	b.Enqueue(1_000)
+
+	assert.Eq(t, base.Count(), 2)
+	assert.Eq(t, base.Peek(), 0)
+	assert.Eq(t, q.Count(), 999)
+	assert.Eq(t, q.Peek(), 1)
+	assert.Eq(t, b.Count(), 999)
+	assert.Eq(t, b.Peek(), 2)
+
+	i := 1
+	q.ForEach(func(val int) {
+		assert.Eq(t, val, i)
+		i++
+	})
+	assert.Eq(t, i, 1_000)
+
+	for b.Count() > 0 {
+		b.Dequeue()
+	}
+	b.Enqueue(1)
+	assert.True(t, b.Queue().Contains(1))
+	assert.Eq(t, b.Queue().Count(), 1)
+}
+
+func TestFailedQueueBuilderDequeue(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	b := NewQueue[int]().Builder()
+	b.Dequeue()
+}
+
+func TestFailedQueueDequeue(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	q := NewQueue[int]()
+	q.Dequeue()
+}
+
+func BenchmarkQueueEnqueueAndDequeue(b *testing.B) {
+	q := NewQueue[int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		q = q.Enqueue(x)
+		_, q = q.Dequeue()
+	}
+}
+
+func BenchmarkQueueSharedDequeue(b *testing.B) {
+	q := NewQueue[int]()
+	for i := 0; i < 10_000; i++ {
+		q = q.Enqueue(i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		q.Dequeue()
+	}
+}
+
+func BenchmarkQueueBuilderEnqueue(b *testing.B) {
+	q := NewQueue[int]().Builder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		q.Enqueue(x)
+	}
+}
@@ -0,0 +1,87 @@
+package persistent
+
+// Set is an immutable set backed by a hash array mapped trie. Every update
+// returns a new set that shares most of its structure with the original.
+type Set[T comparable] struct {
+	data Map[T, struct{}]
+}
+
+// NewSet is used to create a new empty set.
+func NewSet[T comparable]() Set[T] {
+	return Set[T]{
+		data: NewMap[T, struct{}](),
+	}
+}
+
+// Count returns the amount of entries in the set.
+func (s Set[T]) Count() int {
+	return s.data.Count()
+}
+
+// Empty returns true if the set is empty, false if not.
+func (s Set[T]) Empty() bool {
+	return s.data.Empty()
+}
+
+// Add returns a new set with the value added.
+func (s Set[T]) Add(val T) Set[T] {
+	return Set[T]{data: s.data.Put(val, struct{}{})}
+}
+
+// Remove returns a new set without the value.
+func (s Set[T]) Remove(val T) Set[T] {
+	return Set[T]{data: s.data.Remove(val)}
+}
+
+// Contains returns true if the value exists in the set, false if not.
+func (s Set[T]) Contains(val T) bool {
+	return s.data.ContainsKey(val)
+}
+
+// ForEach iterates over the dataset within the set, calling the passed
+// function for each value.
+func (s Set[T]) ForEach(f func(val T)) {
+	s.data.ForEach(func(key T, val struct{}) {
+		f(key)
+	})
+}
+
+// Builder returns a transient builder starting from the contents of this set.
+// The set itself is never modified.
+func (s Set[T]) Builder() *SetBuilder[T] {
+	return &SetBuilder[T]{
+		data: s.data.Builder(),
+	}
+}
+
+// SetBuilder is a mutable builder used to construct a set quickly.
+type SetBuilder[T comparable] struct {
+	data *MapBuilder[T, struct{}]
+}
+
+// Count returns the amount of entries in the builder.
+func (b *SetBuilder[T]) Count() int {
+	return b.data.Count()
+}
+
+// Contains returns true if the value exists in the builder, false if not.
+func (b *SetBuilder[T]) Contains(val T) bool {
+	_, ok := b.data.Get(val)
+	return ok
+}
+
+// Add adds a value to the builder.
+func (b *SetBuilder[T]) Add(val T) {
+	b.data.Put(val, struct{}{})
+}
+
+// Remove removes a value from the builder.
+func (b *SetBuilder[T]) Remove(val T) {
+	b.data.Remove(val)
+}
+
+// Set returns an immutable set of the builder's contents. The builder can
+// continue to be used afterwards without affecting the returned set.
+func (b *SetBuilder[T]) Set() Set[T] {
+	return Set[T]{data: b.data.Map()}
+}
@@ -0,0 +1,49 @@
+package persistent
+
+import (
+	"testing"
+
+	"github.com/nomad-software/assert"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	s := NewSet[int]()
+	assert.True(t, s.Empty())
+
+	a := s.Add(1).Add(2).Add(2).Add(3)
+	b := a.Remove(2)
+
+	assert.True(t, s.Empty())
+	assert.Eq(t, a.Count(), 3)
+	assert.Eq(t, b.Count(), 2)
+	assert.True(t, a.Contains(2))
+	assert.False(t, b.Contains(2))
+
+	var count int
+	a.ForEach(func(val int) {
+		assert.True(t, val >= 1 && val <= 3)
+		count++
+	})
+	assert.Eq(t, count, 3)
+}
+
+func TestSetBuilder(t *testing.T) {
+	t.Parallel()
+
+	b := NewSet[int]().Builder()
+	for i := 0; i < 1_000; i++ {
+		b.Add(i % 100)
+	}
+	b.Remove(50)
+	assert.Eq(t, b.Count(), 99)
+	assert.False(t, b.Contains(50))
+
+	s := b.Set()
+	b.Add(50)
+
+	assert.Eq(t, s.Count(), 99)
+	assert.False(t, s.Contains(50))
+	assert.True(t, b.Contains(50))
+}
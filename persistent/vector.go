@@ -0,0 +1,358 @@
+package persistent
+
+import (
+	"golang.org/x/exp/slices"
+)
+
+const (
+	vectorBits  = 5
+	vectorWidth = 1 << vectorBits
+	vectorMask  = vectorWidth - 1
+)
+
+// VectorNode is the node type used within the vector trie.
+// Branch nodes hold children, leaf nodes hold values.
+type vectorNode[T comparable] struct {
+	owner    *owner
+	children []*vectorNode[T]
+	vals     []T
+}
+
+// Editable returns a node that can be modified by the passed owner, copying
+// it if it belongs to anyone else.
+func (n *vectorNode[T]) editable(o *owner) *vectorNode[T] {
+	if o != nil && n.owner == o {
+		return n
+	}
+	return &vectorNode[T]{
+		owner:    o,
+		children: slices.Clone(n.children),
+		vals:     slices.Clone(n.vals),
+	}
+}
+
+// Vector is an immutable indexed sequence stored as a 32-way trie. Every
+// update copies only the path to the changed value and returns a new vector.
+// The last values are kept in a separate tail so appending is usually O(1).
+type Vector[T comparable] struct {
+	root  *vectorNode[T]
+	tail  []T
+	count int
+	shift uint
+}
+
+// NewVector is used to create a new empty vector.
+func NewVector[T comparable]() Vector[T] {
+	return Vector[T]{
+		root:  &vectorNode[T]{},
+		shift: vectorBits,
+	}
+}
+
+// Count returns the amount of entries in the vector.
+func (v Vector[T]) Count() int {
+	return v.count
+}
+
+// Empty returns true if the vector is empty, false if not.
+func (v Vector[T]) Empty() bool {
+	return v.Count() == 0
+}
+
+// TailOffset returns the index of the first value held in the tail.
+func (v Vector[T]) tailOffset() int {
+	if v.count < vectorWidth {
+		return 0
+	}
+	return ((v.count - 1) >> vectorBits) << vectorBits
+}
+
+// LeafFor returns the values of the leaf holding the passed index.
+func (v Vector[T]) leafFor(index int) []T {
+	if index >= v.tailOffset() {
+		return v.tail
+	}
+
+	n := v.root
+	for level := v.shift; level > 0; level -= vectorBits {
+		n = n.children[(index>>level)&vectorMask]
+	}
+
+	return n.vals
+}
+
+// Get gets a value at the specified index.
+func (v Vector[T]) Get(index int) T {
+	if index < 0 || index >= v.count {
+		panic("index outside of vector bounds")
+	}
+	return v.leafFor(index)[index&vectorMask]
+}
+
+// Append returns a new vector with the value added to the end.
+func (v Vector[T]) Append(val T) Vector[T] {
+	v.append(val, nil)
+	return v
+}
+
+// Append adds the value to the end of the vector. Nodes belonging to the
+// passed owner are modified in place, all others are copied.
+func (v *Vector[T]) append(val T, o *owner) {
+	if v.count-v.tailOffset() < vectorWidth {
+		if o == nil {
+			tail := make([]T, len(v.tail)+1)
+			copy(tail, v.tail)
+			tail[len(v.tail)] = val
+			v.tail = tail
+		} else {
+			v.tail = append(v.tail, val)
+		}
+		v.count++
+		return
+	}
+
+	if v.root == nil {
+		v.root = &vectorNode[T]{owner: o}
+		v.shift = vectorBits
+	}
+
+	leaf := &vectorNode[T]{owner: o, vals: v.tail}
+
+	if (v.count >> vectorBits) > (1 << v.shift) {
+		v.root = &vectorNode[T]{
+			owner:    o,
+			children: []*vectorNode[T]{v.root, newPath(v.shift, leaf, o)},
+		}
+		v.shift += vectorBits
+	} else {
+		v.root = v.pushTail(v.shift, v.root, leaf, o)
+	}
+
+	if o == nil {
+		v.tail = []T{val}
+	} else {
+		v.tail = make([]T, 1, vectorWidth)
+		v.tail[0] = val
+	}
+
+	v.count++
+}
+
+// NewPath returns a chain of branch nodes leading down to the passed node.
+func newPath[T comparable](level uint, n *vectorNode[T], o *owner) *vectorNode[T] {
+	if level == 0 {
+		return n
+	}
+	return &vectorNode[T]{
+		owner:    o,
+		children: []*vectorNode[T]{newPath(level-vectorBits, n, o)},
+	}
+}
+
+// PushTail adds a full leaf to the trie, returning the new subtree root.
+func (v *Vector[T]) pushTail(level uint, parent *vectorNode[T], leaf *vectorNode[T], o *owner) *vectorNode[T] {
+	index := ((v.count - 1) >> level) & vectorMask
+	result := parent.editable(o)
+
+	var child *vectorNode[T]
+	if level == vectorBits {
+		child = leaf
+	} else if index < len(parent.children) {
+		child = v.pushTail(level-vectorBits, parent.children[index], leaf, o)
+	} else {
+		child = newPath(level-vectorBits, leaf, o)
+	}
+
+	if index < len(result.children) {
+		result.children[index] = child
+	} else {
+		result.children = append(result.children, child)
+	}
+
+	return result
+}
+
+// Update returns a new vector with the value at the specified index replaced.
+func (v Vector[T]) Update(index int, val T) Vector[T] {
+	v.update(index, val, nil)
+	return v
+}
+
+// Update replaces the value at the specified index. Nodes belonging to the
+// passed owner are modified in place, all others are copied.
+func (v *Vector[T]) update(index int, val T, o *owner) {
+	if index < 0 || index >= v.count {
+		panic("index outside of vector bounds")
+	}
+
+	if index >= v.tailOffset() {
+		if o == nil {
+			v.tail = slices.Clone(v.tail)
+		}
+		v.tail[index&vectorMask] = val
+		return
+	}
+
+	v.root = v.assoc(v.shift, v.root, index, val, o)
+}
+
+// Assoc replaces the value at the specified index within the passed subtree,
+// returning the new subtree root.
+func (v *Vector[T]) assoc(level uint, n *vectorNode[T], index int, val T, o *owner) *vectorNode[T] {
+	result := n.editable(o)
+
+	if level == 0 {
+		result.vals[index&vectorMask] = val
+	} else {
+		i := (index >> level) & vectorMask
+		result.children[i] = v.assoc(level-vectorBits, n.children[i], index, val, o)
+	}
+
+	return result
+}
+
+// Pop returns the last value and a new vector without it.
+func (v Vector[T]) Pop() (T, Vector[T]) {
+	if v.count == 0 {
+		panic("vector empty, popping failed")
+	}
+
+	val := v.Get(v.count - 1)
+
+	if v.count == 1 {
+		return val, NewVector[T]()
+	}
+
+	if v.count-v.tailOffset() > 1 {
+		return val, Vector[T]{
+			root:  v.root,
+			tail:  v.tail[: len(v.tail)-1 : len(v.tail)-1],
+			count: v.count - 1,
+			shift: v.shift,
+		}
+	}
+
+	tail := v.leafFor(v.count - 2)
+	root := v.popTail(v.shift, v.root)
+	shift := v.shift
+
+	if root == nil {
+		root = &vectorNode[T]{}
+	}
+
+	if shift > vectorBits && len(root.children) == 1 {
+		root = root.children[0]
+		shift -= vectorBits
+	}
+
+	return val, Vector[T]{
+		root:  root,
+		tail:  tail,
+		count: v.count - 1,
+		shift: shift,
+	}
+}
+
+// PopTail removes the last leaf from the passed subtree, returning the new
+// subtree root or nil if the subtree is now empty.
+func (v Vector[T]) popTail(level uint, n *vectorNode[T]) *vectorNode[T] {
+	index := ((v.count - 2) >> level) & vectorMask
+
+	if level > vectorBits {
+		child := v.popTail(level-vectorBits, n.children[index])
+		if child == nil && index == 0 {
+			return nil
+		}
+
+		result := n.editable(nil)
+		if child == nil {
+			result.children = result.children[:index]
+		} else {
+			result.children[index] = child
+		}
+		return result
+	}
+
+	if index == 0 {
+		return nil
+	}
+
+	result := n.editable(nil)
+	result.children = result.children[:index]
+	return result
+}
+
+// Contains returns true if the value exists in the vector, false if not.
+func (v Vector[T]) Contains(val T) bool {
+	var found bool
+	v.ForEach(func(i int, v T) {
+		if v == val {
+			found = true
+		}
+	})
+	return found
+}
+
+// ForEach iterates over the dataset within the vector, calling the passed
+// function for each value.
+func (v Vector[T]) ForEach(f func(i int, val T)) {
+	for i := 0; i < v.count; i += vectorWidth {
+		for j, val := range v.leafFor(i) {
+			f(i+j, val)
+		}
+	}
+}
+
+// Builder returns a transient builder starting from the contents of this
+// vector. The vector itself is never modified.
+func (v Vector[T]) Builder() *VectorBuilder[T] {
+	tail := make([]T, len(v.tail), vectorWidth)
+	copy(tail, v.tail)
+
+	return &VectorBuilder[T]{
+		vec: Vector[T]{
+			root:  v.root,
+			tail:  tail,
+			count: v.count,
+			shift: v.shift,
+		},
+		owner: &owner{},
+	}
+}
+
+// VectorBuilder is a mutable builder used to construct a vector quickly.
+// Nodes created by the builder are modified in place until the vector is
+// returned, avoiding a copy for every update.
+type VectorBuilder[T comparable] struct {
+	vec   Vector[T]
+	owner *owner
+}
+
+// Count returns the amount of entries in the builder.
+func (b *VectorBuilder[T]) Count() int {
+	return b.vec.Count()
+}
+
+// Get gets a value at the specified index.
+func (b *VectorBuilder[T]) Get(index int) T {
+	return b.vec.Get(index)
+}
+
+// Append adds a value to the end of the builder.
+func (b *VectorBuilder[T]) Append(val T) {
+	b.vec.append(val, b.owner)
+}
+
+// Update replaces the value at the specified index.
+func (b *VectorBuilder[T]) Update(index int, val T) {
+	b.vec.update(index, val, b.owner)
+}
+
+// Vector returns an immutable vector of the builder's contents. The builder
+// can continue to be used afterwards without affecting the returned vector.
+func (b *VectorBuilder[T]) Vector() Vector[T] {
+	v := b.vec
+	v.tail = slices.Clip(slices.Clone(v.tail))
+	b.owner = &owner{}
+	return v
+}
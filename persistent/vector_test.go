@@ -0,0 +1,168 @@
+package persistent
+
+import (
+	"testing"
+
+	"github.com/nomad-software/assert"
+)
+
+func TestVector(t *testing.T) {
+	t.Parallel()
+
+	v := NewVector[int]()
+	assert.True(t, v.Empty())
+
+	a := v.Append(1).Append(2).Append(3)
+	b := a.Update(1, 20)
+	c := a.Append(4)
+
+	assert.True(t, v.Empty())
+	assert.Eq(t, a.Count(), 3)
+	assert.Eq(t, a.Get(1), 2)
+	assert.Eq(t, b.Get(1), 20)
+	assert.Eq(t, c.Count(), 4)
+	assert.Eq(t, c.Get(3), 4)
+	assert.True(t, a.Contains(3))
+	assert.False(t, a.Contains(4))
+
+	val, d := c.Pop()
+	assert.Eq(t, val, 4)
+	assert.Eq(t, d.Count(), 3)
+	assert.Eq(t, c.Count(), 4)
+}
+
+func TestVectorLargeCapacity(t *testing.T) {
+	t.Parallel()
+
+	limit := 100_000
+	versions := make([]Vector[int], 0)
+	v := NewVector[int]()
+
+	for i := 0; i < limit; i++ {
+		v = v.Append(i)
+		if i%10_000 == 0 {
+			versions = append(versions, v)
+		}
+	}
+
+	assert.Eq(t, v.Count(), limit)
+	for i := 0; i < limit; i++ {
+		assert.Eq(t, v.Get(i), i)
+	}
+
+	for i := 0; i < limit; i += 7 {
+		v = v.Update(i, -i)
+	}
+	for i := 0; i < limit; i++ {
+		if i%7 == 0 {
+			assert.Eq(t, v.Get(i), -i)
+		} else {
+			assert.Eq(t, v.Get(i), i)
+		}
+	}
+
+	for i := limit - 1; i >= 0; i-- {
+		var val int
+		val, v = v.Pop()
+		if i%7 == 0 {
+			assert.Eq(t, val, -i)
+		} else {
+			assert.Eq(t, val, i)
+		}
+		assert.Eq(t, v.Count(), i)
+	}
+	assert.True(t, v.Empty())
+
+	for n, version := range versions {
+		assert.Eq(t, version.Count(), n*10_000+1)
+		version.ForEach(func(i int, val int) {
+			assert.Eq(t, val, i)
+		})
+	}
+}
+
+func TestFailedVectorGet(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	v := NewVector[int]().Append(1)
+	v.Get(1)
+}
+
+func TestFailedVectorPop(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	v := NewVector[int]()
+	v.Pop()
+}
+
+func TestVectorBuilder(t *testing.T) {
+	t.Parallel()
+
+	base := NewVector[int]()
+	for i := 0; i < 100; i++ {
+		base = base.Append(i)
+	}
+
+	b := base.Builder()
+	for i := 100; i < 5_000; i++ {
+		b.Append(i)
+	}
+	b.Update(10, -10)
+	b.Update(4_999, -4_999)
+	assert.Eq(t, b.Count(), 5_000)
+	assert.Eq(t, b.Get(10), -10)
+
+	v := b.Vector()
+
+	b.Update(20, -20)
+	b.Append(5_000)
+
+	assert.Eq(t, base.Count(), 100)
+	assert.Eq(t, base.Get(10), 10)
+	assert.Eq(t, v.Count(), 5_000)
+	assert.Eq(t, v.Get(10), -10)
+	assert.Eq(t, v.Get(20), 20)
+	assert.Eq(t, v.Get(4_999), -4_999)
+	assert.Eq(t, b.Get(20), -20)
+	assert.Eq(t, b.Count(), 5_001)
+
+	v.ForEach(func(i int, val int) {
+		if i != 10 && i != 4_999 {
+			assert.Eq(t, val, i)
+		}
+	})
+}
+
+func BenchmarkVectorAppend(b *testing.B) {
+	v := NewVector[int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		v = v.Append(x)
+	}
+}
+
+func BenchmarkVectorBuilderAppend(b *testing.B) {
+	v := NewVector[int]().Builder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		v.Append(x)
+	}
+}
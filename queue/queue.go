@@ -1,6 +1,13 @@
 package queue
 
+import (
+	"golang.org/x/exp/slices"
+)
+
 // Queue is the main queue type.
+// Copying a queue by assignment shares its underlying storage with the
+// original, so changes to one may corrupt the other. Use Clone to take an
+// independent copy.
 type Queue[T comparable] struct {
 	data []T
 }
@@ -12,6 +19,14 @@ func New[T comparable]() Queue[T] {
 	}
 }
 
+// FromSlice is used to create a new queue from the passed values.
+// The first value in the slice is the first to be dequeued.
+func FromSlice[T comparable](vals []T) Queue[T] {
+	return Queue[T]{
+		data: slices.Clone(vals),
+	}
+}
+
 // Count returns the amount of entries in the queue.
 func (q Queue[T]) Count() int {
 	return len(q.data)
@@ -27,6 +42,11 @@ func (q *Queue[T]) Enqueue(val T) {
 	q.data = append(q.data, val)
 }
 
+// EnqueueAll adds the passed values to the queue in order.
+func (q *Queue[T]) EnqueueAll(vals ...T) {
+	q.data = append(q.data, vals...)
+}
+
 // Peek returns the first value.
 func (q Queue[T]) Peek() T {
 	return q.data[0]
@@ -52,6 +72,14 @@ func (q Queue[T]) Contains(val T) bool {
 	return false
 }
 
+// Drain removes every value from the queue, returning them in the order they
+// would have been dequeued.
+func (q *Queue[T]) Drain() []T {
+	vals := q.data
+	q.Clear()
+	return vals
+}
+
 // Clear empties the entire queue.
 func (q *Queue[T]) Clear() {
 	q.data = q.data[:0:0]
@@ -64,3 +92,21 @@ func (q Queue[T]) ForEach(f func(val T)) {
 		f(v)
 	}
 }
+
+// ToSlice returns the values of the queue in the order they would be dequeued.
+func (q Queue[T]) ToSlice() []T {
+	return slices.Clone(q.data)
+}
+
+// Clone returns a copy of the queue that shares no storage with the original.
+func (q Queue[T]) Clone() Queue[T] {
+	return Queue[T]{
+		data: slices.Clone(q.data),
+	}
+}
+
+// Equal returns true if both queues contain the same values in the same order,
+// false if not.
+func (q Queue[T]) Equal(other Queue[T]) bool {
+	return slices.Equal(q.data, other.data)
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
 )
 
 func TestNew(t *testing.T) {
@@ -182,6 +183,101 @@ func TestForEach(t *testing.T) {
 	})
 }
 
+func TestEnqueueAll(t *testing.T) {
+	t.Parallel()
+
+	q := New[int]()
+	q.Enqueue(1)
+	q.EnqueueAll(2, 3, 4)
+	q.EnqueueAll([]int{5, 6}...)
+	q.EnqueueAll()
+
+	assert.Eq(t, q.Count(), 6)
+
+	for i := 1; i <= 6; i++ {
+		assert.Eq(t, q.Dequeue(), i)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	t.Parallel()
+
+	q := New[int]()
+	q.EnqueueAll(1, 2, 3)
+
+	vals := q.Drain()
+	assert.True(t, slices.Equal(vals, []int{1, 2, 3}))
+	assert.True(t, q.Empty())
+
+	q.Enqueue(4)
+	assert.True(t, slices.Equal(vals, []int{1, 2, 3}))
+	assert.Eq(t, len(q.Drain()), 1)
+	assert.Eq(t, len(q.Drain()), 0)
+}
+
+func TestSlices(t *testing.T) {
+	t.Parallel()
+
+	vals := []int{1, 2, 3}
+	q := FromSlice(vals)
+	vals[0] = 10
+
+	assert.Eq(t, q.Count(), 3)
+	assert.Eq(t, q.Peek(), 1)
+	assert.True(t, slices.Equal(q.ToSlice(), []int{1, 2, 3}))
+
+	q.Dequeue()
+	q.Enqueue(4)
+	assert.True(t, slices.Equal(q.ToSlice(), []int{2, 3, 4}))
+	assert.True(t, FromSlice(q.ToSlice()).Equal(q))
+	assert.True(t, FromSlice[int](nil).Empty())
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	q := New[int]()
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3)
+
+	c := q.Clone()
+	assert.True(t, c.Equal(q))
+
+	c.Dequeue()
+	c.Enqueue(4)
+	q.Enqueue(5)
+
+	assert.False(t, c.Equal(q))
+	assert.Eq(t, q.Count(), 4)
+	assert.Eq(t, c.Count(), 3)
+
+	for _, v := range []int{1, 2, 3, 5} {
+		assert.Eq(t, q.Dequeue(), v)
+	}
+	for _, v := range []int{2, 3, 4} {
+		assert.Eq(t, c.Dequeue(), v)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := New[string]()
+	b := New[string]()
+	assert.True(t, a.Equal(b))
+
+	a.Enqueue("foo")
+	a.Enqueue("bar")
+	b.Enqueue("bar")
+	b.Enqueue("foo")
+	assert.False(t, a.Equal(b))
+
+	b.Dequeue()
+	b.Enqueue("bar")
+	assert.True(t, a.Equal(b))
+}
+
 func BenchmarkQueueEnqueueAndDequeue(b *testing.B) {
 	q := New[int]()
 
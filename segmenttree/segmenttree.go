@@ -0,0 +1,153 @@
+package segmenttree
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// Number is the constraint for values that can be summed.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// SegmentTree is the main segment tree type.
+// Values are stored in the second half of the data slice, with every other
+// entry holding the combination of its two children. Updating a copy made by
+// assignment writes into the same data slice as the original, so both see the
+// change. Clone gives a copy with its own slice.
+type SegmentTree[T comparable] struct {
+	data    []T
+	count   int
+	combine func(a T, b T) T
+}
+
+// New is used to create a new segment tree from the passed values.
+// The passed function combines two values into one and is used to answer range
+// queries. It must be associative but doesn't need to be commutative.
+func New[T comparable](vals []T, combine func(a T, b T) T) SegmentTree[T] {
+	s := SegmentTree[T]{
+		data:    make([]T, 2*len(vals)),
+		count:   len(vals),
+		combine: combine,
+	}
+
+	copy(s.data[s.count:], vals)
+
+	for i := s.count - 1; i > 0; i-- {
+		s.data[i] = s.combine(s.data[2*i], s.data[2*i+1])
+	}
+
+	return s
+}
+
+// NewSum is used to create a new segment tree answering range sum queries.
+func NewSum[T Number](vals []T) SegmentTree[T] {
+	return New(vals, func(a, b T) T { return a + b })
+}
+
+// NewMin is used to create a new segment tree answering range minimum queries.
+func NewMin[T constraints.Ordered](vals []T) SegmentTree[T] {
+	return New(vals, func(a, b T) T { return min(a, b) })
+}
+
+// NewMax is used to create a new segment tree answering range maximum queries.
+func NewMax[T constraints.Ordered](vals []T) SegmentTree[T] {
+	return New(vals, func(a, b T) T { return max(a, b) })
+}
+
+// Count returns the amount of entries in the segment tree.
+func (s SegmentTree[T]) Count() int {
+	return s.count
+}
+
+// Empty returns true if the segment tree is empty, false if not.
+func (s SegmentTree[T]) Empty() bool {
+	return s.Count() == 0
+}
+
+// Get gets the value at the specified index.
+func (s SegmentTree[T]) Get(index int) T {
+	if index < 0 || index >= s.count {
+		panic("index outside of segment tree bounds")
+	}
+	return s.data[s.count+index]
+}
+
+// Update updates the value at the specified index.
+func (s *SegmentTree[T]) Update(index int, val T) {
+	if index < 0 || index >= s.count {
+		panic("index outside of segment tree bounds")
+	}
+
+	i := s.count + index
+	s.data[i] = val
+
+	for i > 1 {
+		i /= 2
+		s.data[i] = s.combine(s.data[2*i], s.data[2*i+1])
+	}
+}
+
+// Query returns the combination of the values from index from up to, but not
+// including, index to.
+func (s SegmentTree[T]) Query(from int, to int) T {
+	if from < 0 || to > s.count || from >= to {
+		panic("invalid segment tree query range")
+	}
+
+	var left, right T
+	var hasLeft, hasRight bool
+
+	for l, r := from+s.count, to+s.count; l < r; l, r = l/2, r/2 {
+		if l%2 == 1 {
+			if hasLeft {
+				left = s.combine(left, s.data[l])
+			} else {
+				left, hasLeft = s.data[l], true
+			}
+			l++
+		}
+		if r%2 == 1 {
+			r--
+			if hasRight {
+				right = s.combine(s.data[r], right)
+			} else {
+				right, hasRight = s.data[r], true
+			}
+		}
+	}
+
+	if !hasLeft {
+		return right
+	}
+
+	if !hasRight {
+		return left
+	}
+
+	return s.combine(left, right)
+}
+
+// ForEach iterates over the dataset within the segment tree, calling the
+// passed function for each value.
+func (s SegmentTree[T]) ForEach(f func(i int, val T)) {
+	for i, v := range s.data[s.count:] {
+		f(i, v)
+	}
+}
+
+// Clone returns a copy of the segment tree that shares no storage with the
+// original. The copy uses the same combining function as the original.
+func (s SegmentTree[T]) Clone() SegmentTree[T] {
+	return SegmentTree[T]{
+		data:    slices.Clone(s.data),
+		count:   s.count,
+		combine: s.combine,
+	}
+}
+
+// Equal returns true if both segment trees contain the same values in the
+// same order, false if not. The combining functions are not compared.
+func (s SegmentTree[T]) Equal(other SegmentTree[T]) bool {
+	return slices.Equal(s.data[s.count:], other.data[other.count:])
+}
@@ -0,0 +1,185 @@
+package segmenttree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nomad-software/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	s := NewSum([]int{5, 8, 6, 3, 2, 7, 2, 6})
+	assert.False(t, s.Empty())
+	assert.Eq(t, s.Count(), 8)
+	assert.Eq(t, s.Get(0), 5)
+	assert.Eq(t, s.Get(7), 6)
+
+	assert.True(t, NewSum([]int{}).Empty())
+}
+
+func TestSum(t *testing.T) {
+	t.Parallel()
+
+	s := NewSum([]int{5, 8, 6, 3, 2, 7, 2})
+	assert.Eq(t, s.Query(0, 7), 33)
+	assert.Eq(t, s.Query(2, 5), 11)
+	assert.Eq(t, s.Query(6, 7), 2)
+
+	s.Update(3, 10)
+	assert.Eq(t, s.Get(3), 10)
+	assert.Eq(t, s.Query(0, 7), 40)
+	assert.Eq(t, s.Query(2, 5), 18)
+}
+
+func TestMinMax(t *testing.T) {
+	t.Parallel()
+
+	vals := []float64{3.5, -1, 4, 1.5, 5, 9, -2.5, 6}
+	lo := NewMin(vals)
+	hi := NewMax(vals)
+
+	assert.Eq(t, lo.Query(0, 8), -2.5)
+	assert.Eq(t, lo.Query(0, 6), -1.0)
+	assert.Eq(t, hi.Query(0, 8), 9.0)
+	assert.Eq(t, hi.Query(0, 5), 5.0)
+
+	lo.Update(6, 10)
+	hi.Update(5, 0)
+	assert.Eq(t, lo.Query(2, 8), 1.5)
+	assert.Eq(t, hi.Query(0, 8), 6.0)
+}
+
+func TestNonCommutative(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+
+	for n := 1; n <= 33; n++ {
+		vals := make([]string, n)
+		for i := range vals {
+			vals[i] = string(rune('a' + r.Intn(26)))
+		}
+
+		s := New(vals, func(a, b string) string { return a + b })
+
+		for from := 0; from < n; from++ {
+			for to := from + 1; to <= n; to++ {
+				var expected string
+				for _, v := range vals[from:to] {
+					expected += v
+				}
+				assert.Eq(t, s.Query(from, to), expected)
+			}
+		}
+	}
+}
+
+func TestFailedQuery(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	s := NewSum([]int{1, 2, 3})
+	s.Query(2, 2)
+}
+
+func TestFailedUpdate(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	s := NewSum([]int{1, 2, 3})
+	s.Update(3, 1)
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	s := NewMax([]int{0, 1, 2, 3, 4})
+
+	var count int
+	s.ForEach(func(i int, val int) {
+		assert.Eq(t, val, i)
+		count++
+	})
+	assert.Eq(t, count, 5)
+}
+
+func TestLargeCapacity(t *testing.T) {
+	t.Parallel()
+
+	limit := 1_000_000
+	vals := make([]int, limit)
+	for i := range vals {
+		vals[i] = i + 1
+	}
+
+	s := NewSum(vals)
+	assert.Eq(t, s.Query(0, limit), limit*(limit+1)/2)
+
+	for i := 0; i < limit; i++ {
+		s.Update(i, 1)
+	}
+	assert.Eq(t, s.Query(0, limit), limit)
+	assert.Eq(t, s.Query(1_000, 2_000), 1_000)
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	s := NewSum([]int{1, 2, 3, 4})
+
+	c := s.Clone()
+	assert.True(t, c.Equal(s))
+
+	c.Update(0, 10)
+	s.Update(3, 0)
+
+	assert.False(t, c.Equal(s))
+	assert.Eq(t, s.Query(0, 4), 6)
+	assert.Eq(t, c.Query(0, 4), 19)
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := NewSum([]int{1, 2, 3})
+	b := NewMax([]int{1, 2, 3})
+	assert.True(t, a.Equal(b))
+
+	b.Update(1, 5)
+	assert.False(t, a.Equal(b))
+	assert.False(t, a.Equal(NewSum([]int{1, 2})))
+}
+
+func BenchmarkSegmentTreeUpdate(b *testing.B) {
+	s := NewSum(make([]int, 1_000_000))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		s.Update(x%1_000_000, x)
+	}
+}
+
+func BenchmarkSegmentTreeQuery(b *testing.B) {
+	s := NewSum(make([]int, 1_000_000))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		s.Query(x%500_000, 500_000+x%500_000)
+	}
+}
@@ -1,8 +1,15 @@
 package set
 
-import "github.com/nomad-software/goad/hashmap"
+import (
+	"github.com/nomad-software/goad/hashmap"
+	"golang.org/x/exp/slices"
+)
 
 // Set is the main set type.
+// A set is a thin wrapper around a hash map, so a copy made by assignment
+// shares its buckets but keeps its own count. Values added through one copy may
+// appear in the other without being counted, until a resize leaves them apart
+// altogether. Use Clone to take a set of its own.
 type Set[T comparable] struct {
 	data hashmap.HashMap[T, any]
 }
@@ -14,6 +21,23 @@ func New[T comparable]() Set[T] {
 	}
 }
 
+// FromSlice is used to create a new set containing the passed values.
+func FromSlice[T comparable](vals []T) Set[T] {
+	s := New[T]()
+	s.AddAll(vals...)
+	return s
+}
+
+// FromMap is used to create a new set containing the keys of the passed map.
+func FromMap[T comparable](vals map[T]struct{}) Set[T] {
+	s := New[T]()
+	s.data.Grow(len(vals))
+	for v := range vals {
+		s.data.Put(v, nil)
+	}
+	return s
+}
+
 // Count returns the amount of entries in the set.
 func (s Set[T]) Count() int {
 	return s.data.Count()
@@ -29,9 +53,44 @@ func (s *Set[T]) Add(value T) {
 	s.data.Put(value, nil)
 }
 
-// Remove removes a value from the set.
-func (s *Set[T]) Remove(value T) {
-	s.data.Remove(value)
+// AddAll adds the passed values to the set.
+func (s *Set[T]) AddAll(values ...T) {
+	s.data.Grow(len(values))
+	for _, v := range values {
+		s.data.Put(v, nil)
+	}
+}
+
+// Remove removes a value from the set, returning true if it was present or
+// false if not.
+func (s *Set[T]) Remove(value T) bool {
+	_, ok := s.data.Remove(value)
+	return ok
+}
+
+// Pop removes and returns an arbitrary value from the set, or returns false if
+// the set is empty.
+func (s *Set[T]) Pop() (val T, ok bool) {
+	val, _, ok = s.data.Pop()
+	return val, ok
+}
+
+// RemoveIf removes every value for which the passed predicate returns true,
+// returning the amount of values removed. The set is resized at most once,
+// after every value has been checked.
+func (s *Set[T]) RemoveIf(pred func(val T) bool) int {
+	return s.data.RemoveIf(func(key T, _ any) bool {
+		return pred(key)
+	})
+}
+
+// Retain removes every value for which the passed predicate returns false,
+// returning the amount of values removed. The set is resized at most once,
+// after every value has been checked.
+func (s *Set[T]) Retain(pred func(val T) bool) int {
+	return s.data.Retain(func(key T, _ any) bool {
+		return pred(key)
+	})
 }
 
 // Contains returns true if the value exists in the set, false if not.
@@ -51,3 +110,53 @@ func (s Set[T]) ForEach(f func(val T)) {
 		f(key)
 	})
 }
+
+// ToSlice returns the values of the set in no particular order.
+func (s Set[T]) ToSlice() []T {
+	vals := make([]T, 0, s.Count())
+	s.ForEach(func(val T) {
+		vals = append(vals, val)
+	})
+	return vals
+}
+
+// ToSortedSlice returns the values of the set sorted using the passed function.
+// The function is a predicate that returns true if the first parameter is less
+// than the second.
+func (s Set[T]) ToSortedSlice(less func(a T, b T) bool) []T {
+	vals := s.ToSlice()
+	slices.SortFunc(vals, less)
+	return vals
+}
+
+// ToMap returns a map whose keys are the values of the set.
+func (s Set[T]) ToMap() map[T]struct{} {
+	vals := make(map[T]struct{}, s.Count())
+	s.ForEach(func(val T) {
+		vals[val] = struct{}{}
+	})
+	return vals
+}
+
+// Clone returns a copy of the set that shares no storage with the original.
+func (s Set[T]) Clone() Set[T] {
+	return Set[T]{
+		data: s.data.Clone(),
+	}
+}
+
+// Equal returns true if both sets contain the same values, false if not.
+func (s Set[T]) Equal(other Set[T]) bool {
+	if s.Count() != other.Count() {
+		return false
+	}
+
+	result := true
+	s.ForEach(func(val T) {
+		if !other.Contains(val) {
+			result = false
+		}
+	})
+
+	return result
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
 )
 
 func TestNew(t *testing.T) {
@@ -39,6 +40,63 @@ func TestContains(t *testing.T) {
 	assert.False(t, s.Contains("fuz"))
 }
 
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	s := FromSlice([]string{"foo", "bar", "baz"})
+
+	assert.True(t, s.Remove("foo"))
+	assert.False(t, s.Remove("foo"))
+	assert.False(t, s.Contains("foo"))
+	assert.Eq(t, s.Count(), 2)
+
+	a, ok := s.Pop()
+	assert.True(t, ok)
+	b, ok := s.Pop()
+	assert.True(t, ok)
+	assert.True(t, a != b)
+	assert.True(t, (a == "bar" || a == "baz") && (b == "bar" || b == "baz"))
+
+	_, ok = s.Pop()
+	assert.False(t, ok)
+	assert.True(t, s.Empty())
+}
+
+func TestRemoveIf(t *testing.T) {
+	t.Parallel()
+
+	s := New[int]()
+	for i := 0; i < 1_000; i++ {
+		s.Add(i)
+	}
+
+	assert.Eq(t, s.RemoveIf(func(val int) bool { return val%2 == 0 }), 500)
+	assert.Eq(t, s.Retain(func(val int) bool { return val < 100 }), 450)
+	assert.Eq(t, s.Count(), 50)
+
+	for i := 0; i < 100; i++ {
+		assert.Eq(t, s.Contains(i), i%2 == 1)
+	}
+}
+
+func TestLargeCapacity(t *testing.T) {
+	t.Parallel()
+
+	s := New[int]()
+	limit := 100_000
+
+	for i := 0; i < limit; i++ {
+		s.Add(i)
+	}
+
+	for i := 0; i < limit; i++ {
+		assert.True(t, s.Contains(i))
+		assert.False(t, s.Contains(limit+i))
+	}
+
+	assert.True(t, s.Equal(s.Clone()))
+}
+
 func TestClearing(t *testing.T) {
 	t.Parallel()
 
@@ -80,6 +138,108 @@ func TestForEach(t *testing.T) {
 	})
 }
 
+func TestAddAll(t *testing.T) {
+	t.Parallel()
+
+	s := New[string]()
+	s.Add("foo")
+	s.AddAll("foo", "bar", "baz")
+	s.AddAll()
+
+	assert.Eq(t, s.Count(), 3)
+	assert.True(t, s.Contains("bar"))
+	assert.True(t, s.Contains("baz"))
+}
+
+func TestSlices(t *testing.T) {
+	t.Parallel()
+
+	s := FromSlice([]int{3, 1, 2, 3})
+	assert.Eq(t, s.Count(), 3)
+
+	vals := s.ToSlice()
+	assert.Eq(t, len(vals), 3)
+	assert.True(t, FromSlice(vals).Equal(s))
+
+	assert.True(t, slices.Equal(s.ToSortedSlice(func(a, b int) bool { return a < b }), []int{1, 2, 3}))
+	assert.True(t, slices.Equal(s.ToSortedSlice(func(a, b int) bool { return a > b }), []int{3, 2, 1}))
+}
+
+func TestMaps(t *testing.T) {
+	t.Parallel()
+
+	s := FromMap(map[string]struct{}{"foo": {}, "bar": {}})
+	assert.Eq(t, s.Count(), 2)
+	assert.True(t, s.Contains("foo"))
+	assert.True(t, s.Contains("bar"))
+
+	s.Add("baz")
+	vals := s.ToMap()
+	assert.Eq(t, len(vals), 3)
+
+	_, ok := vals["baz"]
+	assert.True(t, ok)
+	assert.True(t, FromMap(vals).Equal(s))
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	s := New[int]()
+	s.Add(1)
+	s.Add(2)
+	s.Add(3)
+
+	c := s.Clone()
+	assert.True(t, c.Equal(s))
+
+	c.Remove(1)
+	s.Add(4)
+
+	assert.False(t, c.Equal(s))
+	assert.Eq(t, s.Count(), 4)
+	assert.Eq(t, c.Count(), 2)
+	assert.True(t, s.Contains(1))
+	assert.False(t, c.Contains(1))
+	assert.False(t, c.Contains(4))
+}
+
+func TestAssignment(t *testing.T) {
+	t.Parallel()
+
+	s := New[int]()
+	s.Add(1)
+
+	// Assignment shares the buckets but not the count, so the copies disagree.
+	a := s
+	a.Add(2)
+	assert.True(t, s.Contains(2))
+	assert.Eq(t, s.Count(), 1)
+	assert.Eq(t, a.Count(), 2)
+
+	c := a.Clone()
+	c.Add(3)
+	assert.False(t, a.Contains(3))
+	assert.Eq(t, a.Count(), 2)
+	assert.Eq(t, c.Count(), 3)
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := New[string]()
+	b := New[string]()
+	assert.True(t, a.Equal(b))
+
+	a.Add("foo")
+	a.Add("bar")
+	b.Add("bar")
+	assert.False(t, a.Equal(b))
+
+	b.Add("foo")
+	assert.True(t, a.Equal(b))
+}
+
 func BenchmarkSetAdd(b *testing.B) {
 	m := New[string]()
 
@@ -106,6 +266,21 @@ func BenchmarkSetRemove(b *testing.B) {
 	}
 }
 
+func BenchmarkSetContains(b *testing.B) {
+	s := New[int]()
+
+	for x := 0; x < 1_000_000; x++ {
+		s.Add(x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		s.Contains(x % 1_000_000)
+	}
+}
+
 func BenchmarkSetForEach(b *testing.B) {
 	m := New[int]()
 
@@ -0,0 +1,221 @@
+package singlylinkedlist
+
+// Node is the node type used within the linked list.
+type node[T comparable] struct {
+	next *node[T]
+	val  T
+}
+
+// SinglyLinkedList is the main linked list type.
+// Every node only links to the next, so nodes are smaller than those of a
+// doubly linked list but values can only be removed from the front. A copy
+// made by assignment points at the same chain of nodes as the original, and
+// inserting through one relinks nodes the other still believes it holds.
+// Clone copies the nodes.
+type SinglyLinkedList[T comparable] struct {
+	first *node[T]
+	last  *node[T]
+	count int
+}
+
+// New is used to create a new linked list.
+func New[T comparable]() SinglyLinkedList[T] {
+	return SinglyLinkedList[T]{}
+}
+
+// FromSlice is used to create a new linked list from the passed values.
+func FromSlice[T comparable](vals []T) SinglyLinkedList[T] {
+	l := New[T]()
+	for _, v := range vals {
+		l.InsertLast(v)
+	}
+	return l
+}
+
+// Count returns the amount of entries in the linked list.
+func (l SinglyLinkedList[T]) Count() int {
+	return l.count
+}
+
+// Empty returns true if the linked list is empty, false if not.
+func (l SinglyLinkedList[T]) Empty() bool {
+	return l.Count() == 0
+}
+
+// InsertFirst inserts a value at the beginning of the linked list.
+func (l *SinglyLinkedList[T]) InsertFirst(val T) {
+	l.first = &node[T]{val: val, next: l.first}
+
+	if l.last == nil {
+		l.last = l.first
+	}
+
+	l.count++
+}
+
+// First returns the value at the beginning of the linked list.
+func (l SinglyLinkedList[T]) First() T {
+	if l.first == nil {
+		panic("linked list empty, getting first failed")
+	}
+
+	return l.first.val
+}
+
+// RemoveFirst removes and returns the first value in the linked list.
+func (l *SinglyLinkedList[T]) RemoveFirst() T {
+	if l.first == nil {
+		panic("linked list empty, removing first failed")
+	}
+
+	val := l.first.val
+	l.first = l.first.next
+
+	if l.first == nil {
+		l.last = nil
+	}
+
+	l.count--
+	return val
+}
+
+// InsertLast inserts a value at the end of the linked list.
+func (l *SinglyLinkedList[T]) InsertLast(val T) {
+	n := &node[T]{val: val}
+
+	if l.last == nil {
+		l.first = n
+	} else {
+		l.last.next = n
+	}
+
+	l.last = n
+	l.count++
+}
+
+// Last returns the value at the end of the linked list.
+func (l SinglyLinkedList[T]) Last() T {
+	if l.last == nil {
+		panic("linked list empty, getting last failed")
+	}
+
+	return l.last.val
+}
+
+// Get gets a value at the specified index.
+func (l SinglyLinkedList[T]) Get(index int) T {
+	if index < 0 || index >= l.Count() {
+		panic("index outside of linked list bounds")
+	}
+
+	if index == l.Count()-1 {
+		return l.last.val
+	}
+
+	n := l.first
+	for i := 0; i < index; i++ {
+		n = n.next
+	}
+
+	return n.val
+}
+
+// IndexOf returns the index of the first occurrence of the value, or -1 if the
+// value doesn't exist in the linked list.
+func (l SinglyLinkedList[T]) IndexOf(val T) int {
+	var index int
+
+	for ln := l.first; ln != nil; ln = ln.next {
+		if ln.val == val {
+			return index
+		}
+		index++
+	}
+
+	return -1
+}
+
+// Contains returns true if the value exists in the linked list, false if not.
+func (l SinglyLinkedList[T]) Contains(val T) bool {
+	return l.IndexOf(val) >= 0
+}
+
+// Reverse reverses the order of the values in the linked list in place.
+func (l *SinglyLinkedList[T]) Reverse() {
+	var prev *node[T]
+
+	for ln := l.first; ln != nil; {
+		next := ln.next
+		ln.next = prev
+		prev = ln
+		ln = next
+	}
+
+	l.first, l.last = l.last, l.first
+}
+
+// Clear empties the entire linked list.
+func (l *SinglyLinkedList[T]) Clear() {
+	l.first = nil
+	l.last = nil
+	l.count = 0
+}
+
+// ForEach iterates over the dataset within the linked list, calling the passed
+// function for each value.
+func (l SinglyLinkedList[T]) ForEach(f func(i int, val T)) {
+	var index int
+
+	for ln := l.first; ln != nil; ln = ln.next {
+		f(index, ln.val)
+		index++
+	}
+}
+
+// ForEachWhile iterates over the dataset within the linked list, calling the
+// passed function for each value until it returns false.
+func (l SinglyLinkedList[T]) ForEachWhile(f func(i int, val T) bool) {
+	var index int
+
+	for ln := l.first; ln != nil; ln = ln.next {
+		if !f(index, ln.val) {
+			return
+		}
+		index++
+	}
+}
+
+// ToSlice returns the values of the linked list in order.
+func (l SinglyLinkedList[T]) ToSlice() []T {
+	vals := make([]T, 0, l.Count())
+	for ln := l.first; ln != nil; ln = ln.next {
+		vals = append(vals, ln.val)
+	}
+	return vals
+}
+
+// Clone returns a copy of the linked list that shares no nodes with the
+// original.
+func (l SinglyLinkedList[T]) Clone() SinglyLinkedList[T] {
+	c := New[T]()
+	for ln := l.first; ln != nil; ln = ln.next {
+		c.InsertLast(ln.val)
+	}
+	return c
+}
+
+// Equal returns true if both linked lists contain the same values in the same
+// order, false if not.
+func (l SinglyLinkedList[T]) Equal(other SinglyLinkedList[T]) bool {
+	if l.Count() != other.Count() {
+		return false
+	}
+
+	for a, b := l.first, other.first; a != nil; a, b = a.next, b.next {
+		if a.val != b.val {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,246 @@
+package singlylinkedlist
+
+import (
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"github.com/nomad-software/goad/linkedlist"
+	"golang.org/x/exp/slices"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	l := New[int]()
+	assert.True(t, l.Empty())
+	assert.Eq(t, l.Count(), 0)
+}
+
+func TestInsertFirst(t *testing.T) {
+	t.Parallel()
+
+	l := New[int]()
+	l.InsertFirst(3)
+	l.InsertFirst(2)
+	l.InsertFirst(1)
+
+	assert.Eq(t, l.Count(), 3)
+	assert.Eq(t, l.First(), 1)
+	assert.Eq(t, l.Last(), 3)
+
+	assert.Eq(t, l.RemoveFirst(), 1)
+	assert.Eq(t, l.RemoveFirst(), 2)
+	assert.Eq(t, l.RemoveFirst(), 3)
+	assert.True(t, l.Empty())
+
+	l.InsertFirst(4)
+	assert.Eq(t, l.Last(), 4)
+}
+
+func TestInsertLast(t *testing.T) {
+	t.Parallel()
+
+	l := New[string]()
+	l.InsertLast("a")
+	l.InsertLast("b")
+	l.InsertFirst("c")
+
+	assert.True(t, slices.Equal(l.ToSlice(), []string{"c", "a", "b"}))
+	assert.Eq(t, l.Last(), "b")
+
+	l.RemoveFirst()
+	l.RemoveFirst()
+	l.RemoveFirst()
+	l.InsertLast("d")
+	assert.Eq(t, l.First(), "d")
+	assert.Eq(t, l.Last(), "d")
+}
+
+func TestFailedFirst(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	l := New[int]()
+	l.First()
+}
+
+func TestFailedRemoveFirst(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	l := New[int]()
+	l.RemoveFirst()
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{0, 1, 2, 3})
+	for i := 0; i < 4; i++ {
+		assert.Eq(t, l.Get(i), i)
+	}
+}
+
+func TestFailedGet(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	l := FromSlice([]int{0, 1})
+	l.Get(2)
+}
+
+func TestContains(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{1, 2, 3, 2})
+	assert.True(t, l.Contains(2))
+	assert.False(t, l.Contains(4))
+	assert.Eq(t, l.IndexOf(2), 1)
+	assert.Eq(t, l.IndexOf(4), -1)
+}
+
+func TestReverse(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{1, 2, 3})
+	l.Reverse()
+
+	assert.True(t, slices.Equal(l.ToSlice(), []int{3, 2, 1}))
+	assert.Eq(t, l.First(), 3)
+	assert.Eq(t, l.Last(), 1)
+
+	l.InsertLast(0)
+	assert.True(t, slices.Equal(l.ToSlice(), []int{3, 2, 1, 0}))
+}
+
+func TestClear(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{1, 2, 3})
+	l.Clear()
+	assert.True(t, l.Empty())
+
+	l.InsertLast(4)
+	assert.Eq(t, l.Count(), 1)
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{0, 1, 2, 3, 4})
+
+	l.ForEach(func(i int, val int) {
+		assert.Eq(t, val, i)
+	})
+
+	var calls int
+	l.ForEachWhile(func(i int, val int) bool {
+		calls++
+		return val < 2
+	})
+	assert.Eq(t, calls, 3)
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	l := FromSlice([]int{1, 2, 3})
+
+	c := l.Clone()
+	assert.True(t, c.Equal(l))
+
+	c.RemoveFirst()
+	l.InsertLast(4)
+
+	assert.False(t, c.Equal(l))
+	assert.True(t, slices.Equal(l.ToSlice(), []int{1, 2, 3, 4}))
+	assert.True(t, slices.Equal(c.ToSlice(), []int{2, 3}))
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := FromSlice([]int{1, 2})
+	b := FromSlice([]int{2, 1})
+	assert.False(t, a.Equal(b))
+
+	b.Reverse()
+	assert.True(t, a.Equal(b))
+	assert.False(t, a.Equal(New[int]()))
+}
+
+func BenchmarkSinglyLinkedListInsertAndRemove(b *testing.B) {
+	l := New[int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		l.InsertFirst(x)
+		l.RemoveFirst()
+	}
+}
+
+func BenchmarkLinkedListInsertAndRemove(b *testing.B) {
+	l := linkedlist.New[int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		l.InsertFirst(x)
+		l.RemoveFirst()
+	}
+}
+
+func BenchmarkSinglyLinkedListAppend(b *testing.B) {
+	b.ReportAllocs()
+
+	for x := 0; x < b.N; x++ {
+		l := New[int]()
+		for i := 0; i < 1_000; i++ {
+			l.InsertLast(i)
+		}
+	}
+}
+
+func BenchmarkLinkedListAppend(b *testing.B) {
+	b.ReportAllocs()
+
+	for x := 0; x < b.N; x++ {
+		l := linkedlist.New[int]()
+		for i := 0; i < 1_000; i++ {
+			l.InsertLast(i)
+		}
+	}
+}
+
+func BenchmarkSinglyLinkedListForEach(b *testing.B) {
+	l := New[int]()
+
+	for x := 0; x < 1_000_000; x++ {
+		l.InsertLast(x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		l.ForEach(func(index int, val int) {})
+	}
+}
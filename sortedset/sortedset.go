@@ -0,0 +1,416 @@
+package sortedset
+
+import (
+	"golang.org/x/exp/slices"
+)
+
+// Node is the node type used within the sorted set.
+// Every node is augmented with the size of its subtree to support rank
+// queries.
+type node[T comparable] struct {
+	left   *node[T]
+	right  *node[T]
+	val    T
+	height int
+	size   int
+}
+
+// SortedSet is the main sorted set type.
+// The set is a tree reached through a single root, so a copy made by
+// assignment shares every node and rebalancing through one copy rearranges the
+// tree of the other. Clone copies the tree.
+type SortedSet[T comparable] struct {
+	root *node[T]
+	less func(a T, b T) bool
+}
+
+// New is used to create a new sorted set.
+// The passed function is a predicate that returns true if the first parameter
+// is less than the second. This predicate defines the sorting order between
+// the set values. Values where neither is less than the other are considered
+// equal and are only stored once.
+func New[T comparable](less func(a T, b T) bool) SortedSet[T] {
+	return SortedSet[T]{
+		less: less,
+	}
+}
+
+// Count returns the amount of entries in the sorted set.
+func (s SortedSet[T]) Count() int {
+	return size(s.root)
+}
+
+// Empty returns true if the sorted set is empty, false if not.
+func (s SortedSet[T]) Empty() bool {
+	return s.Count() == 0
+}
+
+// Height returns the height of the passed node.
+func height[T comparable](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// Size returns the size of the subtree rooted at the passed node.
+func size[T comparable](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// Update recalculates the height and size of the passed node from its
+// children.
+func (n *node[T]) update() {
+	n.height = 1 + max(height(n.left), height(n.right))
+	n.size = 1 + size(n.left) + size(n.right)
+}
+
+// RotateLeft rotates the passed node left, returning the new subtree root.
+func rotateLeft[T comparable](n *node[T]) *node[T] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+// RotateRight rotates the passed node right, returning the new subtree root.
+func rotateRight[T comparable](n *node[T]) *node[T] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+// Balance restores the AVL balance of the passed node, returning the new
+// subtree root.
+func balance[T comparable](n *node[T]) *node[T] {
+	n.update()
+
+	if height(n.left)-height(n.right) > 1 {
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	}
+
+	if height(n.right)-height(n.left) > 1 {
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+
+	return n
+}
+
+// Add adds a value to the sorted set.
+func (s *SortedSet[T]) Add(val T) {
+	s.root = s.add(s.root, val)
+}
+
+// Add recursively inserts the value into the passed subtree, returning the
+// new subtree root.
+func (s *SortedSet[T]) add(n *node[T], val T) *node[T] {
+	if n == nil {
+		return &node[T]{val: val, height: 1, size: 1}
+	}
+
+	if s.less(val, n.val) {
+		n.left = s.add(n.left, val)
+	} else if s.less(n.val, val) {
+		n.right = s.add(n.right, val)
+	} else {
+		n.val = val
+		return n
+	}
+
+	return balance(n)
+}
+
+// Remove removes a value from the sorted set.
+func (s *SortedSet[T]) Remove(val T) {
+	s.root = s.remove(s.root, val)
+}
+
+// Remove recursively removes the value from the passed subtree, returning the
+// new subtree root.
+func (s *SortedSet[T]) remove(n *node[T], val T) *node[T] {
+	if n == nil {
+		return nil
+	}
+
+	if s.less(val, n.val) {
+		n.left = s.remove(n.left, val)
+	} else if s.less(n.val, val) {
+		n.right = s.remove(n.right, val)
+	} else {
+		if n.left == nil {
+			return n.right
+		}
+
+		if n.right == nil {
+			return n.left
+		}
+
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+
+		n.val = successor.val
+		n.right = removeMin(n.right)
+	}
+
+	return balance(n)
+}
+
+// RemoveMin removes the smallest node from the passed subtree, returning the
+// new subtree root.
+func removeMin[T comparable](n *node[T]) *node[T] {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = removeMin(n.left)
+	return balance(n)
+}
+
+// Contains returns true if the value exists in the sorted set, false if not.
+func (s SortedSet[T]) Contains(val T) bool {
+	for n := s.root; n != nil; {
+		if s.less(val, n.val) {
+			n = n.left
+		} else if s.less(n.val, val) {
+			n = n.right
+		} else {
+			return true
+		}
+	}
+	return false
+}
+
+// Min returns the smallest value in the sorted set.
+func (s SortedSet[T]) Min() T {
+	if s.root == nil {
+		panic("sorted set empty, getting min failed")
+	}
+
+	n := s.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n.val
+}
+
+// Max returns the largest value in the sorted set.
+func (s SortedSet[T]) Max() T {
+	if s.root == nil {
+		panic("sorted set empty, getting max failed")
+	}
+
+	n := s.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.val
+}
+
+// Rank returns the amount of values in the sorted set that are less than the
+// passed value. If the value exists this is its index in sorted order.
+func (s SortedSet[T]) Rank(val T) int {
+	var rank int
+
+	for n := s.root; n != nil; {
+		if s.less(n.val, val) {
+			rank += size(n.left) + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+
+	return rank
+}
+
+// At returns the value at the passed index in sorted order.
+func (s SortedSet[T]) At(rank int) T {
+	if rank < 0 || rank >= s.Count() {
+		panic("rank outside of sorted set bounds")
+	}
+
+	n := s.root
+	for {
+		left := size(n.left)
+		if rank < left {
+			n = n.left
+		} else if rank > left {
+			rank -= left + 1
+			n = n.right
+		} else {
+			return n.val
+		}
+	}
+}
+
+// Clear empties the entire sorted set.
+func (s *SortedSet[T]) Clear() {
+	s.root = nil
+}
+
+// ForEach iterates over the dataset within the sorted set in sorted order,
+// calling the passed function for each value.
+func (s SortedSet[T]) ForEach(f func(val T)) {
+	forEach(s.root, f)
+}
+
+// ForEach recursively walks the passed subtree in order.
+func forEach[T comparable](n *node[T], f func(val T)) {
+	if n == nil {
+		return
+	}
+	forEach(n.left, f)
+	f(n.val)
+	forEach(n.right, f)
+}
+
+// ForEachRange iterates in sorted order over the values that are greater or
+// equal to from and less than to, calling the passed function for each value.
+func (s SortedSet[T]) ForEachRange(from T, to T, f func(val T)) {
+	s.forEachRange(s.root, from, to, f)
+}
+
+// ForEachRange recursively walks the passed subtree in order, skipping any
+// subtree that lies outside of the range.
+func (s SortedSet[T]) forEachRange(n *node[T], from T, to T, f func(val T)) {
+	if n == nil {
+		return
+	}
+
+	afterFrom := !s.less(n.val, from)
+	beforeTo := s.less(n.val, to)
+
+	if afterFrom {
+		s.forEachRange(n.left, from, to, f)
+	}
+
+	if afterFrom && beforeTo {
+		f(n.val)
+	}
+
+	if beforeTo {
+		s.forEachRange(n.right, from, to, f)
+	}
+}
+
+// Slice returns the values of the set in sorted order.
+func (s SortedSet[T]) slice() []T {
+	vals := make([]T, 0, s.Count())
+	s.ForEach(func(val T) {
+		vals = append(vals, val)
+	})
+	return vals
+}
+
+// Build returns a perfectly balanced subtree containing the passed sorted
+// values.
+func build[T comparable](vals []T) *node[T] {
+	if len(vals) == 0 {
+		return nil
+	}
+
+	mid := len(vals) / 2
+	n := &node[T]{
+		val:   vals[mid],
+		left:  build(vals[:mid]),
+		right: build(vals[mid+1:]),
+	}
+	n.update()
+
+	return n
+}
+
+// Clone returns a copy of the sorted set that shares no nodes with the
+// original. The copy uses the same predicate as the original.
+func (s SortedSet[T]) Clone() SortedSet[T] {
+	return SortedSet[T]{
+		root: build(s.slice()),
+		less: s.less,
+	}
+}
+
+// Equal returns true if both sorted sets contain the same values in the same
+// order, false if not.
+func (s SortedSet[T]) Equal(other SortedSet[T]) bool {
+	return slices.Equal(s.slice(), other.slice())
+}
+
+// Merge walks two sorted slices together, keeping values according to the
+// passed flags for values only in a, only in b, or in both. Values are
+// returned in sorted order.
+func (s SortedSet[T]) merge(a []T, b []T, onlyA bool, onlyB bool, both bool) SortedSet[T] {
+	result := make([]T, 0, len(a)+len(b))
+	var i, j int
+
+	for i < len(a) && j < len(b) {
+		if s.less(a[i], b[j]) {
+			if onlyA {
+				result = append(result, a[i])
+			}
+			i++
+		} else if s.less(b[j], a[i]) {
+			if onlyB {
+				result = append(result, b[j])
+			}
+			j++
+		} else {
+			if both {
+				result = append(result, a[i])
+			}
+			i++
+			j++
+		}
+	}
+
+	if onlyA {
+		result = append(result, a[i:]...)
+	}
+
+	if onlyB {
+		result = append(result, b[j:]...)
+	}
+
+	return SortedSet[T]{
+		root: build(result),
+		less: s.less,
+	}
+}
+
+// Union returns a new sorted set containing the values found in either set.
+// Both sets must share the same ordering.
+func (s SortedSet[T]) Union(other SortedSet[T]) SortedSet[T] {
+	return s.merge(s.slice(), other.slice(), true, true, true)
+}
+
+// Intersection returns a new sorted set containing the values found in both
+// sets. Both sets must share the same ordering.
+func (s SortedSet[T]) Intersection(other SortedSet[T]) SortedSet[T] {
+	return s.merge(s.slice(), other.slice(), false, false, true)
+}
+
+// Difference returns a new sorted set containing the values found in this set
+// but not in the other. Both sets must share the same ordering.
+func (s SortedSet[T]) Difference(other SortedSet[T]) SortedSet[T] {
+	return s.merge(s.slice(), other.slice(), true, false, false)
+}
+
+// SymmetricDifference returns a new sorted set containing the values found in
+// only one of the sets. Both sets must share the same ordering.
+func (s SortedSet[T]) SymmetricDifference(other SortedSet[T]) SortedSet[T] {
+	return s.merge(s.slice(), other.slice(), true, true, false)
+}
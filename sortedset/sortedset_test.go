@@ -0,0 +1,291 @@
+package sortedset
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
+)
+
+func ascending(a, b int) bool { return a < b }
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	s := New(ascending)
+	assert.True(t, s.Empty())
+
+	s.Add(5)
+	s.Add(1)
+	s.Add(3)
+	s.Add(3)
+	s.Add(9)
+
+	assert.False(t, s.Empty())
+	assert.Eq(t, s.Count(), 4)
+	assert.Eq(t, s.Min(), 1)
+	assert.Eq(t, s.Max(), 9)
+	assert.True(t, s.Contains(3))
+	assert.False(t, s.Contains(4))
+
+	s.Remove(3)
+	s.Remove(4)
+	assert.Eq(t, s.Count(), 3)
+	assert.False(t, s.Contains(3))
+}
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+
+	type Foo struct {
+		Foo int
+		Bar string
+	}
+
+	s := New(func(a, b Foo) bool { return a.Foo > b.Foo })
+	s.Add(Foo{Foo: 1, Bar: "foo"})
+	s.Add(Foo{Foo: 3, Bar: "bar"})
+	s.Add(Foo{Foo: 2, Bar: "baz"})
+	s.Add(Foo{Foo: 2, Bar: "qux"})
+
+	assert.Eq(t, s.Count(), 3)
+	assert.Eq(t, s.Min(), Foo{Foo: 3, Bar: "bar"})
+	assert.Eq(t, s.Max(), Foo{Foo: 1, Bar: "foo"})
+	assert.Eq(t, s.At(1), Foo{Foo: 2, Bar: "qux"})
+}
+
+func TestFailedMin(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	s := New(ascending)
+	s.Min()
+}
+
+func TestRank(t *testing.T) {
+	t.Parallel()
+
+	s := New(ascending)
+	for i := 0; i < 100; i += 10 {
+		s.Add(i)
+	}
+
+	assert.Eq(t, s.Rank(0), 0)
+	assert.Eq(t, s.Rank(50), 5)
+	assert.Eq(t, s.Rank(55), 6)
+	assert.Eq(t, s.Rank(-1), 0)
+	assert.Eq(t, s.Rank(1_000), 10)
+
+	for i := 0; i < s.Count(); i++ {
+		assert.Eq(t, s.At(i), i*10)
+		assert.Eq(t, s.Rank(s.At(i)), i)
+	}
+}
+
+func TestFailedAt(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	s := New(ascending)
+	s.Add(1)
+	s.At(1)
+}
+
+func TestForEachRange(t *testing.T) {
+	t.Parallel()
+
+	s := New(ascending)
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+	}
+
+	vals := make([]int, 0)
+	s.ForEachRange(25, 30, func(val int) {
+		vals = append(vals, val)
+	})
+	assert.True(t, slices.Equal(vals, []int{25, 26, 27, 28, 29}))
+
+	vals = vals[:0]
+	s.ForEachRange(98, 200, func(val int) {
+		vals = append(vals, val)
+	})
+	assert.True(t, slices.Equal(vals, []int{98, 99}))
+
+	s.ForEachRange(50, 50, func(val int) {
+		t.Errorf("empty range iterated")
+	})
+}
+
+func TestSetAlgebra(t *testing.T) {
+	t.Parallel()
+
+	a := New(ascending)
+	b := New(ascending)
+
+	for _, v := range []int{1, 3, 5, 7, 9} {
+		a.Add(v)
+	}
+	for _, v := range []int{3, 4, 5, 6} {
+		b.Add(v)
+	}
+
+	values := func(s SortedSet[int]) []int {
+		vals := make([]int, 0)
+		s.ForEach(func(val int) {
+			vals = append(vals, val)
+		})
+		return vals
+	}
+
+	assert.True(t, slices.Equal(values(a.Union(b)), []int{1, 3, 4, 5, 6, 7, 9}))
+	assert.True(t, slices.Equal(values(a.Intersection(b)), []int{3, 5}))
+	assert.True(t, slices.Equal(values(a.Difference(b)), []int{1, 7, 9}))
+	assert.True(t, slices.Equal(values(b.Difference(a)), []int{4, 6}))
+	assert.True(t, slices.Equal(values(a.SymmetricDifference(b)), []int{1, 4, 6, 7, 9}))
+
+	u := a.Union(b)
+	u.Add(2)
+	assert.Eq(t, u.At(1), 2)
+	assert.Eq(t, a.Count(), 5)
+}
+
+func TestClearing(t *testing.T) {
+	t.Parallel()
+
+	s := New(ascending)
+	s.Add(1)
+	s.Add(2)
+
+	s.Clear()
+	assert.True(t, s.Empty())
+
+	s.Add(1)
+	assert.Eq(t, s.Count(), 1)
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	s := New(ascending)
+	for _, v := range []int{5, 2, 4, 1, 3} {
+		s.Add(v)
+	}
+
+	i := 1
+	s.ForEach(func(val int) {
+		assert.Eq(t, val, i)
+		i++
+	})
+
+	s.Clear()
+	s.ForEach(func(val int) {
+		t.Errorf("sorted set not cleared")
+	})
+}
+
+func TestRandomised(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+	s := New(ascending)
+	expected := make(map[int]bool)
+
+	for i := 0; i < 20_000; i++ {
+		v := r.Intn(5_000)
+		if r.Intn(3) == 0 {
+			s.Remove(v)
+			delete(expected, v)
+		} else {
+			s.Add(v)
+			expected[v] = true
+		}
+	}
+
+	assert.Eq(t, s.Count(), len(expected))
+	assert.True(t, s.root.height <= 18)
+
+	sorted := make([]int, 0, len(expected))
+	for v := range expected {
+		sorted = append(sorted, v)
+	}
+	slices.Sort(sorted)
+
+	for i, v := range sorted {
+		assert.Eq(t, s.At(i), v)
+		assert.Eq(t, s.Rank(v), i)
+	}
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	s := New(ascending)
+	s.Add(1)
+	s.Add(2)
+	s.Add(3)
+
+	c := s.Clone()
+	assert.True(t, c.Equal(s))
+
+	c.Remove(1)
+	s.Add(4)
+
+	assert.False(t, c.Equal(s))
+	assert.Eq(t, s.Count(), 4)
+	assert.Eq(t, c.Count(), 2)
+	assert.Eq(t, s.Min(), 1)
+	assert.Eq(t, c.Max(), 3)
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := New(ascending)
+	b := New(ascending)
+	assert.True(t, a.Equal(b))
+
+	a.Add(1)
+	a.Add(2)
+	b.Add(2)
+	assert.False(t, a.Equal(b))
+
+	b.Add(1)
+	assert.True(t, a.Equal(b))
+}
+
+func BenchmarkSortedSetAdd(b *testing.B) {
+	s := New(ascending)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		s.Add(x)
+	}
+}
+
+func BenchmarkSortedSetRank(b *testing.B) {
+	s := New(ascending)
+
+	for x := 0; x < 1_000_000; x++ {
+		s.Add(x)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		s.Rank(500_000)
+	}
+}
@@ -1,8 +1,21 @@
 package stack
 
+import (
+	"errors"
+
+	"golang.org/x/exp/slices"
+)
+
+// ErrOverflow is returned when pushing onto a bounded stack that is full.
+var ErrOverflow = errors.New("stack overflow")
+
 // Stack is the main stack type.
+// Copying a stack by assignment shares its underlying storage with the
+// original, so changes to one may corrupt the other. Use Clone to take an
+// independent copy.
 type Stack[T comparable] struct {
-	data []T
+	data  []T
+	limit int
 }
 
 // New is used to create a new stack.
@@ -12,6 +25,33 @@ func New[T comparable]() Stack[T] {
 	}
 }
 
+// NewBounded is used to create a new stack that can hold at most the passed
+// amount of values. Pushing onto a full bounded stack panics, use TryPush,
+// TryPushAll, TryPushN, TryDup or TryOver to receive an error instead.
+func NewBounded[T comparable](limit int) Stack[T] {
+	if limit < 1 {
+		panic("stack bound must be greater than zero")
+	}
+
+	return Stack[T]{
+		data:  make([]T, 0, min(limit, 16)),
+		limit: limit,
+	}
+}
+
+// FromSlice is used to create a new stack from the passed values.
+// The first value in the slice is placed on top of the stack, mirroring the
+// order returned by ToSlice.
+func FromSlice[T comparable](vals []T) Stack[T] {
+	s := Stack[T]{
+		data: make([]T, len(vals)),
+	}
+	for i, v := range vals {
+		s.data[len(vals)-1-i] = v
+	}
+	return s
+}
+
 // Count returns the amount of entries in the stack.
 func (s Stack[T]) Count() int {
 	return len(s.data)
@@ -22,9 +62,99 @@ func (s Stack[T]) Empty() bool {
 	return s.Count() == 0
 }
 
+// Full returns true if the stack is bounded and holds as many values as it
+// can, false if not.
+func (s Stack[T]) Full() bool {
+	return s.limit > 0 && s.Count() >= s.limit
+}
+
+// Fits returns true if another n values fit on the stack, false if not.
+func (s Stack[T]) fits(n int) bool {
+	return s.limit == 0 || s.Count()+n <= s.limit
+}
+
+// Reserve panics if another n values won't fit on a bounded stack.
+func (s Stack[T]) reserve(n int) {
+	if !s.fits(n) {
+		panic("stack overflow, pushing failed")
+	}
+}
+
+// Require panics if the stack holds less than n values.
+func (s Stack[T]) require(n int, op string) {
+	if n < 0 || s.Count() < n {
+		panic("stack too shallow, " + op + " failed")
+	}
+}
+
 // Push adds a value to the stack.
 func (s *Stack[T]) Push(val T) {
+	s.reserve(1)
+	s.data = append(s.data, val)
+}
+
+// TryPush adds a value to the stack, returning ErrOverflow if the stack is
+// bounded and already full.
+func (s *Stack[T]) TryPush(val T) error {
+	if !s.fits(1) {
+		return ErrOverflow
+	}
 	s.data = append(s.data, val)
+	return nil
+}
+
+// PushAll adds the passed values to the stack in order, leaving the last value
+// on top.
+func (s *Stack[T]) PushAll(vals ...T) {
+	s.reserve(len(vals))
+	s.data = append(s.data, vals...)
+}
+
+// TryPushAll adds the passed values to the stack in order, leaving the last
+// value on top. It returns ErrOverflow without pushing any values if they
+// don't all fit on a bounded stack.
+func (s *Stack[T]) TryPushAll(vals ...T) error {
+	if !s.fits(len(vals)) {
+		return ErrOverflow
+	}
+	s.data = append(s.data, vals...)
+	return nil
+}
+
+// PushN adds the passed values to the stack in reverse order, leaving the
+// first value on top. It is the inverse of PopN.
+func (s *Stack[T]) PushN(vals ...T) {
+	s.reserve(len(vals))
+	s.pushReversed(vals)
+}
+
+// TryPushN adds the passed values to the stack in reverse order, leaving the
+// first value on top. It returns ErrOverflow without pushing any values if
+// they don't all fit on a bounded stack.
+func (s *Stack[T]) TryPushN(vals ...T) error {
+	if !s.fits(len(vals)) {
+		return ErrOverflow
+	}
+	s.pushReversed(vals)
+	return nil
+}
+
+// PushReversed adds the passed values to the stack in reverse order.
+func (s *Stack[T]) pushReversed(vals []T) {
+	n := s.Count()
+	if cap(s.data)-n < len(vals) {
+		s.data = slices.Grow(s.data, len(vals))
+	}
+	s.data = s.data[:n+len(vals)]
+	dst := s.data[n:]
+
+	// The values may have been returned by PopN and so overlap the storage
+	// they're pushed into. Copy handles any overlap, so copy them in order
+	// first and then reverse them in place.
+	copy(dst, vals)
+	for i, j := 0, len(dst)-1; i < j; i, j = i+1, j-1 {
+		dst[i], dst[j] = dst[j], dst[i]
+	}
 }
 
 // Peek returns the first value.
@@ -32,6 +162,15 @@ func (s Stack[T]) Peek() T {
 	return s.data[s.Count()-1]
 }
 
+// PeekAt returns the value at the passed depth, where the top of the stack is
+// at depth zero.
+func (s Stack[T]) PeekAt(depth int) T {
+	if depth < 0 || depth >= s.Count() {
+		panic("depth outside of stack bounds")
+	}
+	return s.data[s.Count()-1-depth]
+}
+
 // Pop returns the first value and removes it.
 func (s *Stack[T]) Pop() T {
 	if s.Empty() {
@@ -43,6 +182,80 @@ func (s *Stack[T]) Pop() T {
 	return val
 }
 
+// PopN removes the top n values, returning them in the order they were popped.
+// The values are reversed in place, so the returned slice shares storage with
+// the stack and is overwritten by later pushes. Copy it to keep the values,
+// though it, or any part of it, can be passed straight to PushN.
+func (s *Stack[T]) PopN(n int) []T {
+	s.require(n, "popping")
+
+	vals := s.data[s.Count()-n : s.Count() : s.Count()]
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		vals[i], vals[j] = vals[j], vals[i]
+	}
+	s.data = s.data[0 : s.Count()-n]
+
+	return vals
+}
+
+// Swap exchanges the top two values.
+func (s *Stack[T]) Swap() {
+	s.require(2, "swapping")
+
+	i := s.Count() - 1
+	s.data[i], s.data[i-1] = s.data[i-1], s.data[i]
+}
+
+// Dup pushes a copy of the top value.
+func (s *Stack[T]) Dup() {
+	s.require(1, "duplicating")
+	s.Push(s.data[s.Count()-1])
+}
+
+// TryDup pushes a copy of the top value, returning ErrOverflow if the stack is
+// bounded and already full.
+func (s *Stack[T]) TryDup() error {
+	s.require(1, "duplicating")
+	return s.TryPush(s.data[s.Count()-1])
+}
+
+// Over pushes a copy of the value beneath the top value.
+func (s *Stack[T]) Over() {
+	s.require(2, "copying over")
+	s.Push(s.data[s.Count()-2])
+}
+
+// TryOver pushes a copy of the value beneath the top value, returning
+// ErrOverflow if the stack is bounded and already full.
+func (s *Stack[T]) TryOver() error {
+	s.require(2, "copying over")
+	return s.TryPush(s.data[s.Count()-2])
+}
+
+// Rotate moves the value at depth n-1 to the top of the stack, shifting the
+// values above it down by one. Rotate(2) is equivalent to Swap and Rotate(3)
+// is the classic three value rotation.
+func (s *Stack[T]) Rotate(n int) {
+	s.require(n, "rotating")
+
+	if n < 2 {
+		return
+	}
+
+	i := s.Count() - n
+	val := s.data[i]
+	copy(s.data[i:], s.data[i+1:])
+	s.data[s.Count()-1] = val
+}
+
+// Reverse reverses the order of the values in the stack, so the bottom value
+// is on top.
+func (s *Stack[T]) Reverse() {
+	for i, j := 0, s.Count()-1; i < j; i, j = i+1, j-1 {
+		s.data[i], s.data[j] = s.data[j], s.data[i]
+	}
+}
+
 // Contains returns true if the value exists in the stack, false if not.
 func (s Stack[T]) Contains(val T) bool {
 	for _, v := range s.data {
@@ -53,6 +266,15 @@ func (s Stack[T]) Contains(val T) bool {
 	return false
 }
 
+// Drain removes every value from the stack, returning them in the order they
+// would have been popped.
+func (s *Stack[T]) Drain() []T {
+	s.Reverse()
+	vals := s.data
+	s.Clear()
+	return vals
+}
+
 // Clear empties the entire stack.
 func (s *Stack[T]) Clear() {
 	s.data = s.data[:0:0]
@@ -65,3 +287,26 @@ func (s Stack[T]) ForEach(f func(val T)) {
 		f(s.data[i])
 	}
 }
+
+// ToSlice returns the values of the stack in the order they would be popped.
+func (s Stack[T]) ToSlice() []T {
+	vals := make([]T, 0, len(s.data))
+	s.ForEach(func(val T) {
+		vals = append(vals, val)
+	})
+	return vals
+}
+
+// Clone returns a copy of the stack that shares no storage with the original.
+func (s Stack[T]) Clone() Stack[T] {
+	return Stack[T]{
+		data:  slices.Clone(s.data),
+		limit: s.limit,
+	}
+}
+
+// Equal returns true if both stacks contain the same values in the same order,
+// false if not. The bounds of the stacks are not compared.
+func (s Stack[T]) Equal(other Stack[T]) bool {
+	return slices.Equal(s.data, other.data)
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
 )
 
 func TestNew(t *testing.T) {
@@ -182,6 +183,316 @@ func TestForEach(t *testing.T) {
 	})
 }
 
+func TestPushAll(t *testing.T) {
+	t.Parallel()
+
+	s := New[int]()
+	s.Push(1)
+	s.PushAll(2, 3, 4)
+	s.PushAll([]int{5, 6}...)
+	s.PushAll()
+
+	assert.Eq(t, s.Count(), 6)
+
+	for i := 6; i >= 1; i-- {
+		assert.Eq(t, s.Pop(), i)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	t.Parallel()
+
+	s := New[int]()
+	s.PushAll(1, 2, 3)
+
+	vals := s.Drain()
+	assert.True(t, slices.Equal(vals, []int{3, 2, 1}))
+	assert.True(t, s.Empty())
+
+	s.Push(4)
+	assert.True(t, slices.Equal(vals, []int{3, 2, 1}))
+	assert.Eq(t, len(s.Drain()), 1)
+	assert.Eq(t, len(s.Drain()), 0)
+}
+
+func TestSlices(t *testing.T) {
+	t.Parallel()
+
+	vals := []int{3, 2, 1}
+	s := FromSlice(vals)
+	vals[0] = 10
+
+	assert.Eq(t, s.Count(), 3)
+	assert.Eq(t, s.Peek(), 3)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{3, 2, 1}))
+
+	s.Push(4)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{4, 3, 2, 1}))
+	assert.True(t, FromSlice(s.ToSlice()).Equal(s))
+	assert.True(t, FromSlice[int](nil).Empty())
+}
+
+func TestPeekAt(t *testing.T) {
+	t.Parallel()
+
+	s := FromSlice([]int{1, 2, 3})
+	assert.Eq(t, s.PeekAt(0), 1)
+	assert.Eq(t, s.PeekAt(1), 2)
+	assert.Eq(t, s.PeekAt(2), 3)
+	assert.Eq(t, s.Count(), 3)
+}
+
+func TestFailedPeekAt(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	s := FromSlice([]int{1, 2, 3})
+	s.PeekAt(3)
+}
+
+func TestPopN(t *testing.T) {
+	t.Parallel()
+
+	s := New[int]()
+	s.PushAll(1, 2, 3, 4, 5)
+
+	vals := s.PopN(3)
+	assert.True(t, slices.Equal(vals, []int{5, 4, 3}))
+	assert.Eq(t, s.Count(), 2)
+	assert.Eq(t, s.Peek(), 2)
+
+	// The popped values share storage with the stack, so are overwritten by
+	// the next push.
+	kept := slices.Clone(vals)
+	s.Push(6)
+	assert.True(t, slices.Equal(vals, []int{6, 4, 3}))
+	s.Pop()
+
+	s.PushN(kept...)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{5, 4, 3, 2, 1}))
+
+	assert.Eq(t, len(s.PopN(0)), 0)
+	assert.Eq(t, s.Count(), 5)
+}
+
+func TestPopNOverlap(t *testing.T) {
+	t.Parallel()
+
+	s := New[int]()
+	s.PushAll(1, 2, 3, 4, 5, 6)
+
+	// Part of the popped values overlaps the storage it's pushed into.
+	vals := s.PopN(4)
+	assert.True(t, slices.Equal(vals, []int{6, 5, 4, 3}))
+	s.PushN(vals[1:]...)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{5, 4, 3, 2, 1}))
+
+	vals = s.PopN(4)
+	s.PushN(vals[:2]...)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{5, 4, 1}))
+
+	vals = s.PopN(3)
+	s.PushN(vals[1:2]...)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{4}))
+}
+
+func TestPopNAllocs(t *testing.T) {
+	s := New[int]()
+	s.PushAll(1, 2, 3, 4, 5)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		s.PushN(s.PopN(3)...)
+	})
+	assert.Eq(t, allocs, 0.0)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{5, 4, 3, 2, 1}))
+}
+
+func TestFailedPopN(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	s := FromSlice([]int{1, 2})
+	s.PopN(3)
+}
+
+func TestStackOperations(t *testing.T) {
+	t.Parallel()
+
+	s := New[int]()
+	s.PushAll(1, 2, 3)
+
+	s.Swap()
+	assert.True(t, slices.Equal(s.ToSlice(), []int{2, 3, 1}))
+
+	s.Dup()
+	assert.True(t, slices.Equal(s.ToSlice(), []int{2, 2, 3, 1}))
+
+	s.Over()
+	assert.True(t, slices.Equal(s.ToSlice(), []int{2, 2, 2, 3, 1}))
+
+	s.Pop()
+	s.Pop()
+	s.Rotate(3)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{1, 2, 3}))
+
+	s.Rotate(2)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{2, 1, 3}))
+
+	s.Rotate(1)
+	s.Rotate(0)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{2, 1, 3}))
+
+	s.Reverse()
+	assert.True(t, slices.Equal(s.ToSlice(), []int{3, 1, 2}))
+}
+
+func TestFailedOperations(t *testing.T) {
+	t.Parallel()
+
+	ops := map[string]func(s *Stack[int]){
+		"swap":   func(s *Stack[int]) { s.Swap() },
+		"dup":    func(s *Stack[int]) { s.Pop(); s.Dup() },
+		"over":   func(s *Stack[int]) { s.Over() },
+		"rotate": func(s *Stack[int]) { s.Rotate(2) },
+	}
+
+	for name, op := range ops {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("no panic detected for %s", name)
+				}
+			}()
+
+			s := FromSlice([]int{1})
+			op(&s)
+		}()
+	}
+}
+
+func TestBounded(t *testing.T) {
+	t.Parallel()
+
+	s := NewBounded[int](3)
+	assert.False(t, s.Full())
+
+	assert.Eq(t, s.TryPush(1), nil)
+	s.PushAll(2, 3)
+	assert.True(t, s.Full())
+	assert.Eq(t, s.TryPush(4), ErrOverflow)
+	assert.Eq(t, s.Count(), 3)
+
+	s.Pop()
+	assert.Eq(t, s.TryPush(4), nil)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{4, 2, 1}))
+
+	c := s.Clone()
+	assert.True(t, c.Full())
+	assert.Eq(t, c.TryPush(5), ErrOverflow)
+
+	u := New[int]()
+	for i := 0; i < 1_000; i++ {
+		assert.Eq(t, u.TryPush(i), nil)
+	}
+	assert.False(t, u.Full())
+}
+
+func TestBoundedTryPushes(t *testing.T) {
+	t.Parallel()
+
+	s := NewBounded[int](4)
+	assert.Eq(t, s.TryPushAll(1, 2, 3, 4, 5), ErrOverflow)
+	assert.True(t, s.Empty())
+
+	assert.Eq(t, s.TryPushAll(1, 2), nil)
+	assert.Eq(t, s.TryPushN(3, 4, 5), ErrOverflow)
+	assert.Eq(t, s.Count(), 2)
+
+	assert.Eq(t, s.TryDup(), nil)
+	assert.Eq(t, s.TryOver(), nil)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{2, 2, 2, 1}))
+
+	assert.Eq(t, s.TryDup(), ErrOverflow)
+	assert.Eq(t, s.TryOver(), ErrOverflow)
+	assert.Eq(t, s.TryPushN(), nil)
+	assert.Eq(t, s.Count(), 4)
+
+	s.PopN(2)
+	assert.Eq(t, s.TryPushN(3, 4), nil)
+	assert.True(t, slices.Equal(s.ToSlice(), []int{3, 4, 2, 1}))
+}
+
+func TestFailedBoundedPush(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	s := NewBounded[int](2)
+	s.Push(1)
+	s.Dup()
+	s.Over()
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	s := New[int]()
+	s.Push(1)
+	s.Push(2)
+	s.Push(3)
+
+	c := s.Clone()
+	assert.True(t, c.Equal(s))
+
+	c.Pop()
+	c.Push(4)
+	s.Push(5)
+
+	assert.False(t, c.Equal(s))
+	assert.Eq(t, s.Count(), 4)
+	assert.Eq(t, c.Count(), 3)
+
+	for _, v := range []int{5, 3, 2, 1} {
+		assert.Eq(t, s.Pop(), v)
+	}
+	for _, v := range []int{4, 2, 1} {
+		assert.Eq(t, c.Pop(), v)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := New[string]()
+	b := New[string]()
+	assert.True(t, a.Equal(b))
+
+	a.Push("foo")
+	a.Push("bar")
+	b.Push("bar")
+	b.Push("foo")
+	assert.False(t, a.Equal(b))
+
+	b.Clear()
+	b.Push("foo")
+	b.Push("bar")
+	assert.True(t, a.Equal(b))
+}
+
 func BenchmarkStackPushAndPop(b *testing.B) {
 	s := New[int]()
 
@@ -194,6 +505,21 @@ func BenchmarkStackPushAndPop(b *testing.B) {
 	}
 }
 
+func BenchmarkStackRotate(b *testing.B) {
+	s := New[int]()
+	s.PushAll(1, 2, 3, 4, 5)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		s.Rotate(3)
+		s.Swap()
+		s.Dup()
+		s.Pop()
+	}
+}
+
 func BenchmarkStackForEach(b *testing.B) {
 	s := New[int]()
 
@@ -0,0 +1,391 @@
+package unionfind
+
+import (
+	"github.com/nomad-software/goad/hashmap"
+	"golang.org/x/exp/slices"
+)
+
+// UnionFind is the main disjoint set type.
+// The parent, rank and size maps are shared by copies made by assignment but
+// the set count isn't, so a union through one copy merges sets in both while
+// only one of them counts it. Use Clone to copy the maps.
+type UnionFind[T comparable] struct {
+	parent hashmap.HashMap[T, T]
+	rank   hashmap.HashMap[T, int]
+	size   hashmap.HashMap[T, int]
+	sets   int
+}
+
+// New is used to create a new disjoint set.
+func New[T comparable]() UnionFind[T] {
+	return UnionFind[T]{
+		parent: hashmap.New[T, T](),
+		rank:   hashmap.New[T, int](),
+		size:   hashmap.New[T, int](),
+	}
+}
+
+// Count returns the amount of values in the disjoint set.
+func (u UnionFind[T]) Count() int {
+	return u.parent.Count()
+}
+
+// Empty returns true if the disjoint set is empty, false if not.
+func (u UnionFind[T]) Empty() bool {
+	return u.Count() == 0
+}
+
+// SetCount returns the amount of distinct sets.
+func (u UnionFind[T]) SetCount() int {
+	return u.sets
+}
+
+// Add adds a value to the disjoint set in a set of its own. If the value
+// already exists nothing happens.
+func (u *UnionFind[T]) Add(val T) {
+	if !u.Contains(val) {
+		u.parent.Put(val, val)
+		u.rank.Put(val, 0)
+		u.size.Put(val, 1)
+		u.sets++
+	}
+}
+
+// Contains returns true if the value exists in the disjoint set, false if not.
+func (u UnionFind[T]) Contains(val T) bool {
+	_, ok := u.parent.Get(val)
+	return ok
+}
+
+// Root returns the representative value of the set containing the passed
+// value without compressing the path to it.
+func (u UnionFind[T]) root(val T) T {
+	root, ok := u.parent.Get(val)
+	if !ok {
+		panic("value not found in union find")
+	}
+
+	for {
+		p, _ := u.parent.Get(root)
+		if p == root {
+			return root
+		}
+		root = p
+	}
+}
+
+// Find returns the representative value of the set containing the passed
+// value. The path to the representative is compressed along the way.
+func (u *UnionFind[T]) Find(val T) T {
+	root := u.root(val)
+
+	for val != root {
+		p, _ := u.parent.Get(val)
+		u.parent.Put(val, root)
+		val = p
+	}
+
+	return root
+}
+
+// Union merges the sets containing the passed values, adding the values if
+// they don't already exist. Returns true if the sets were merged, false if the
+// values were already in the same set.
+func (u *UnionFind[T]) Union(a T, b T) bool {
+	u.Add(a)
+	u.Add(b)
+
+	a = u.Find(a)
+	b = u.Find(b)
+
+	if a == b {
+		return false
+	}
+
+	rankA, _ := u.rank.Get(a)
+	rankB, _ := u.rank.Get(b)
+
+	if rankA < rankB {
+		a, b = b, a
+	} else if rankA == rankB {
+		u.rank.Put(a, rankA+1)
+	}
+
+	sizeA, _ := u.size.Get(a)
+	sizeB, _ := u.size.Get(b)
+
+	u.parent.Put(b, a)
+	u.size.Put(a, sizeA+sizeB)
+	u.size.Remove(b)
+	u.rank.Remove(b)
+	u.sets--
+
+	return true
+}
+
+// Connected returns true if both values are in the same set, false if not.
+func (u *UnionFind[T]) Connected(a T, b T) bool {
+	if !u.Contains(a) || !u.Contains(b) {
+		return false
+	}
+	return u.Find(a) == u.Find(b)
+}
+
+// SetSize returns the amount of values in the set containing the passed value.
+func (u *UnionFind[T]) SetSize(val T) int {
+	size, _ := u.size.Get(u.Find(val))
+	return size
+}
+
+// Clear empties the entire disjoint set.
+func (u *UnionFind[T]) Clear() {
+	u.parent.Clear()
+	u.rank.Clear()
+	u.size.Clear()
+	u.sets = 0
+}
+
+// ForEach iterates over the values within the disjoint set, calling the passed
+// function for each value.
+func (u UnionFind[T]) ForEach(f func(val T)) {
+	u.parent.ForEach(func(key T, val T) {
+		f(key)
+	})
+}
+
+// ForEachSet iterates over the distinct sets, calling the passed function with
+// the members of each set.
+func (u *UnionFind[T]) ForEachSet(f func(members []T)) {
+	index := hashmap.New[T, int]()
+	sets := make([][]T, 0, u.sets)
+
+	vals := make([]T, 0, u.Count())
+	u.ForEach(func(val T) {
+		vals = append(vals, val)
+	})
+
+	for _, val := range vals {
+		root := u.Find(val)
+		i, ok := index.Get(root)
+		if !ok {
+			i = len(sets)
+			index.Put(root, i)
+			sets = append(sets, make([]T, 0))
+		}
+		sets[i] = append(sets[i], val)
+	}
+
+	for _, members := range sets {
+		f(members)
+	}
+}
+
+// Clone returns a copy of the disjoint set that shares no storage with the
+// original.
+func (u UnionFind[T]) Clone() UnionFind[T] {
+	return UnionFind[T]{
+		parent: u.parent.Clone(),
+		rank:   u.rank.Clone(),
+		size:   u.size.Clone(),
+		sets:   u.sets,
+	}
+}
+
+// Equal returns true if both disjoint sets contain the same values grouped
+// into the same sets, false if not. Paths are not compressed, so neither
+// disjoint set is changed.
+func (u UnionFind[T]) Equal(other UnionFind[T]) bool {
+	if u.Count() != other.Count() || u.SetCount() != other.SetCount() {
+		return false
+	}
+
+	roots := hashmap.New[T, T]()
+	result := true
+
+	u.ForEach(func(val T) {
+		if !result {
+			return
+		}
+
+		if !other.Contains(val) {
+			result = false
+			return
+		}
+
+		a := u.root(val)
+		b := other.root(val)
+
+		if root, ok := roots.Get(a); !ok {
+			roots.Put(a, b)
+		} else if root != b {
+			result = false
+		}
+	})
+
+	return result
+}
+
+// Dense is a disjoint set over the integers 0 to n-1, backed by slices
+// instead of a hash map. Copies made by assignment share these slices, and even
+// Find changes them by compressing paths. Use Clone for an independent copy.
+type Dense struct {
+	parent []int
+	rank   []uint8
+	size   []int
+	sets   int
+}
+
+// NewDense is used to create a new dense disjoint set containing the integers
+// 0 to n-1, each in a set of its own.
+func NewDense(n int) Dense {
+	d := Dense{
+		parent: make([]int, n),
+		rank:   make([]uint8, n),
+		size:   make([]int, n),
+		sets:   n,
+	}
+
+	for i := range d.parent {
+		d.parent[i] = i
+		d.size[i] = 1
+	}
+
+	return d
+}
+
+// Count returns the amount of values in the disjoint set.
+func (d Dense) Count() int {
+	return len(d.parent)
+}
+
+// Empty returns true if the disjoint set is empty, false if not.
+func (d Dense) Empty() bool {
+	return d.Count() == 0
+}
+
+// SetCount returns the amount of distinct sets.
+func (d Dense) SetCount() int {
+	return d.sets
+}
+
+// Root returns the representative value of the set containing the passed
+// value without compressing the path to it.
+func (d Dense) root(val int) int {
+	if val < 0 || val >= d.Count() {
+		panic("value outside of union find bounds")
+	}
+
+	for d.parent[val] != val {
+		val = d.parent[val]
+	}
+	return val
+}
+
+// Find returns the representative value of the set containing the passed
+// value. The path to the representative is compressed along the way.
+func (d *Dense) Find(val int) int {
+	root := d.root(val)
+
+	for val != root {
+		next := d.parent[val]
+		d.parent[val] = root
+		val = next
+	}
+
+	return root
+}
+
+// Union merges the sets containing the passed values. Returns true if the
+// sets were merged, false if the values were already in the same set.
+func (d *Dense) Union(a int, b int) bool {
+	a = d.Find(a)
+	b = d.Find(b)
+
+	if a == b {
+		return false
+	}
+
+	if d.rank[a] < d.rank[b] {
+		a, b = b, a
+	} else if d.rank[a] == d.rank[b] {
+		d.rank[a]++
+	}
+
+	d.parent[b] = a
+	d.size[a] += d.size[b]
+	d.sets--
+
+	return true
+}
+
+// Connected returns true if both values are in the same set, false if not.
+func (d *Dense) Connected(a int, b int) bool {
+	return d.Find(a) == d.Find(b)
+}
+
+// SetSize returns the amount of values in the set containing the passed value.
+func (d *Dense) SetSize(val int) int {
+	return d.size[d.Find(val)]
+}
+
+// ForEachSet iterates over the distinct sets, calling the passed function with
+// the members of each set.
+func (d *Dense) ForEachSet(f func(members []int)) {
+	index := make([]int, d.Count())
+	sets := make([][]int, 0, d.sets)
+
+	for i := range index {
+		index[i] = -1
+	}
+
+	for val := range d.parent {
+		root := d.Find(val)
+		if index[root] < 0 {
+			index[root] = len(sets)
+			sets = append(sets, make([]int, 0, d.size[root]))
+		}
+		sets[index[root]] = append(sets[index[root]], val)
+	}
+
+	for _, members := range sets {
+		f(members)
+	}
+}
+
+// Clone returns a copy of the disjoint set that shares no storage with the
+// original.
+func (d Dense) Clone() Dense {
+	return Dense{
+		parent: slices.Clone(d.parent),
+		rank:   slices.Clone(d.rank),
+		size:   slices.Clone(d.size),
+		sets:   d.sets,
+	}
+}
+
+// Equal returns true if both disjoint sets contain the same values grouped
+// into the same sets, false if not. Paths are not compressed, so neither
+// disjoint set is changed.
+func (d Dense) Equal(other Dense) bool {
+	if d.Count() != other.Count() || d.SetCount() != other.SetCount() {
+		return false
+	}
+
+	roots := make([]int, d.Count())
+	for i := range roots {
+		roots[i] = -1
+	}
+
+	for val := range d.parent {
+		a := d.root(val)
+		b := other.root(val)
+
+		if roots[a] < 0 {
+			roots[a] = b
+		} else if roots[a] != b {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,274 @@
+package unionfind
+
+import (
+	"testing"
+
+	"github.com/nomad-software/assert"
+	"golang.org/x/exp/slices"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	u := New[string]()
+	assert.True(t, u.Empty())
+
+	u.Add("a")
+	u.Add("b")
+	u.Add("c")
+	u.Add("c")
+
+	assert.False(t, u.Empty())
+	assert.Eq(t, u.Count(), 3)
+	assert.Eq(t, u.SetCount(), 3)
+	assert.True(t, u.Contains("a"))
+	assert.False(t, u.Contains("d"))
+	assert.Eq(t, u.Find("a"), "a")
+}
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+
+	u := New[int]()
+
+	assert.True(t, u.Union(1, 2))
+	assert.True(t, u.Union(3, 4))
+	assert.False(t, u.Union(2, 1))
+	assert.Eq(t, u.Count(), 4)
+	assert.Eq(t, u.SetCount(), 2)
+
+	assert.True(t, u.Connected(1, 2))
+	assert.True(t, u.Connected(3, 4))
+	assert.False(t, u.Connected(1, 3))
+	assert.False(t, u.Connected(1, 5))
+	assert.Eq(t, u.SetSize(1), 2)
+
+	assert.True(t, u.Union(2, 4))
+	assert.True(t, u.Connected(1, 3))
+	assert.Eq(t, u.SetCount(), 1)
+	assert.Eq(t, u.SetSize(3), 4)
+	assert.Eq(t, u.Find(1), u.Find(4))
+}
+
+func TestFailedFind(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	u := New[int]()
+	u.Find(1)
+}
+
+func TestClearing(t *testing.T) {
+	t.Parallel()
+
+	u := New[int]()
+	u.Union(1, 2)
+	u.Union(3, 4)
+
+	u.Clear()
+	assert.True(t, u.Empty())
+	assert.Eq(t, u.SetCount(), 0)
+
+	u.Union(1, 2)
+	assert.Eq(t, u.Count(), 2)
+	assert.Eq(t, u.SetCount(), 1)
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	u := New[int]()
+	for i := 0; i < 10; i++ {
+		u.Union(i, i%3)
+	}
+
+	var i int
+	u.ForEach(func(val int) {
+		i++
+	})
+	assert.Eq(t, i, 10)
+
+	var sets int
+	u.ForEachSet(func(members []int) {
+		sets++
+		for _, m := range members {
+			assert.Eq(t, m%3, members[0]%3)
+		}
+		assert.Eq(t, len(members), u.SetSize(members[0]))
+	})
+	assert.Eq(t, sets, 3)
+}
+
+func TestLargeCapacity(t *testing.T) {
+	t.Parallel()
+
+	u := New[int]()
+	limit := 100_000
+
+	for i := 1; i < limit; i++ {
+		u.Union(i-1, i)
+	}
+
+	assert.Eq(t, u.Count(), limit)
+	assert.Eq(t, u.SetCount(), 1)
+	assert.Eq(t, u.SetSize(0), limit)
+	assert.True(t, u.Connected(0, limit-1))
+}
+
+func TestDense(t *testing.T) {
+	t.Parallel()
+
+	d := NewDense(6)
+	assert.False(t, d.Empty())
+	assert.Eq(t, d.Count(), 6)
+	assert.Eq(t, d.SetCount(), 6)
+
+	assert.True(t, d.Union(0, 1))
+	assert.True(t, d.Union(2, 3))
+	assert.True(t, d.Union(1, 3))
+	assert.False(t, d.Union(0, 2))
+
+	assert.Eq(t, d.SetCount(), 3)
+	assert.True(t, d.Connected(0, 3))
+	assert.False(t, d.Connected(0, 4))
+	assert.Eq(t, d.SetSize(2), 4)
+	assert.Eq(t, d.SetSize(5), 1)
+
+	var sets int
+	d.ForEachSet(func(members []int) {
+		sets++
+		assert.Eq(t, len(members), d.SetSize(members[0]))
+	})
+	assert.Eq(t, sets, 3)
+
+	assert.True(t, NewDense(0).Empty())
+}
+
+func TestFailedDenseFind(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	d := NewDense(3)
+	d.Find(3)
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	u := New[int]()
+	u.Union(1, 2)
+	u.Add(3)
+
+	c := u.Clone()
+	assert.True(t, c.Equal(u))
+
+	c.Union(2, 3)
+	u.Union(4, 5)
+
+	assert.False(t, c.Equal(u))
+	assert.False(t, u.Connected(1, 3))
+	assert.True(t, c.Connected(1, 3))
+	assert.False(t, c.Contains(4))
+	assert.Eq(t, u.SetSize(1), 2)
+
+	d := NewDense(4)
+	d.Union(0, 1)
+
+	e := d.Clone()
+	assert.True(t, e.Equal(d))
+
+	e.Union(1, 2)
+	assert.False(t, e.Equal(d))
+	assert.False(t, d.Connected(0, 2))
+	assert.Eq(t, d.SetCount(), 3)
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := New[string]()
+	b := New[string]()
+	assert.True(t, a.Equal(b))
+
+	a.Union("a", "b")
+	a.Union("c", "d")
+	b.Union("a", "c")
+	b.Union("b", "d")
+	assert.False(t, a.Equal(b))
+
+	b.Clear()
+	b.Union("b", "a")
+	b.Union("d", "c")
+	assert.True(t, a.Equal(b))
+
+	x := NewDense(4)
+	y := NewDense(4)
+	x.Union(0, 1)
+	y.Union(2, 3)
+	assert.False(t, x.Equal(y))
+
+	x.Union(2, 3)
+	y.Union(1, 0)
+	assert.True(t, x.Equal(y))
+}
+
+func TestEqualWithoutCompression(t *testing.T) {
+	t.Parallel()
+
+	u := New[int]()
+	u.Union(0, 1)
+	u.Union(2, 3)
+	u.Union(0, 2)
+	c := u.Clone()
+
+	// Comparing never compresses the paths of either disjoint set.
+	before := u.parent.Clone()
+	assert.True(t, u.Equal(c))
+	assert.True(t, c.Equal(u))
+	assert.True(t, u.parent.Equal(before))
+	assert.True(t, c.parent.Equal(before))
+
+	d := NewDense(4)
+	d.Union(0, 1)
+	d.Union(2, 3)
+	d.Union(0, 2)
+	e := d.Clone()
+
+	parents := slices.Clone(d.parent)
+	assert.True(t, d.Equal(e))
+	assert.True(t, e.Equal(d))
+	assert.True(t, slices.Equal(d.parent, parents))
+	assert.True(t, slices.Equal(e.parent, parents))
+}
+
+func BenchmarkUnionFindUnion(b *testing.B) {
+	u := New[int]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		u.Union(x%1000, (x*7)%1000)
+	}
+}
+
+func BenchmarkDenseUnion(b *testing.B) {
+	d := NewDense(1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		d.Union(x%1000, (x*7)%1000)
+	}
+}
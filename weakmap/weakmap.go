@@ -0,0 +1,206 @@
+package weakmap
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+	"weak"
+
+	"github.com/nomad-software/goad/hashmap"
+)
+
+// Key is a weak pointer to a key of the map, along with a hash of the address
+// it points to. Addresses don't change while the key is reachable, so the hash
+// is worked out once when the key is made.
+type key[K any] struct {
+	ptr  weak.Pointer[K]
+	hash uint32
+}
+
+// Hash returns the hash of the address the key points to.
+func (k key[K]) Hash() uint32 {
+	return k.hash
+}
+
+// MakeKey returns the weak key of the passed pointer.
+func makeKey[K any](k *K) key[K] {
+	addr := uint64(uintptr(unsafe.Pointer(k)))
+	return key[K]{
+		ptr:  weak.Make(k),
+		hash: uint32(addr) ^ uint32(addr>>32),
+	}
+}
+
+// Value is a value of the map, along with the cleanup that removes it once its
+// key is collected.
+type value[V comparable] struct {
+	val     V
+	cleanup runtime.Cleanup
+}
+
+// Queue holds the keys that have been collected, waiting to be removed from
+// the map. Cleanups run on their own goroutine, so they only ever add to the
+// queue and leave the map itself to remove entries.
+type queue[K any] struct {
+	mu   sync.Mutex
+	keys []key[K]
+}
+
+// Add adds a collected key to the queue.
+func (q *queue[K]) add(k key[K]) {
+	q.mu.Lock()
+	q.keys = append(q.keys, k)
+	q.mu.Unlock()
+}
+
+// Take removes and returns every key in the queue.
+func (q *queue[K]) take() []key[K] {
+	q.mu.Lock()
+	keys := q.keys
+	q.keys = nil
+	q.mu.Unlock()
+	return keys
+}
+
+// WeakMap is a map with weak keys.
+// Keys are pointers, and holding a key in the map doesn't keep it reachable.
+// Once a key is collected its entry is dropped, so the map can hold data about
+// objects without extending their lifetime. Values must not refer to their
+// keys or the keys will never be collected. Entries are dropped by the next
+// operation on the map after the key is collected, so a weak map isn't safe
+// for concurrent use. The runtime may never collect keys of zero size, or keys
+// of 16 bytes or less that contain no pointers, so these entries may never be
+// dropped.
+type WeakMap[K any, V comparable] struct {
+	data hashmap.HashMap[key[K], value[V]]
+	dead *queue[K]
+}
+
+// New is used to create a new weak map.
+func New[K any, V comparable]() *WeakMap[K, V] {
+	return &WeakMap[K, V]{
+		data: hashmap.New[key[K], value[V]](),
+		dead: &queue[K]{},
+	}
+}
+
+// Purge removes the entries of every key that has been collected.
+func (m *WeakMap[K, V]) purge() {
+	for _, k := range m.dead.take() {
+		m.data.Remove(k)
+	}
+}
+
+// Count returns the amount of entries in the map.
+func (m *WeakMap[K, V]) Count() int {
+	m.purge()
+	return m.data.Count()
+}
+
+// Empty returns true if the map is empty, false if not.
+func (m *WeakMap[K, V]) Empty() bool {
+	return m.Count() == 0
+}
+
+// Put adds a value to the map relating to the passed key.
+func (m *WeakMap[K, V]) Put(k *K, val V) {
+	if k == nil {
+		panic("key is nil, putting failed")
+	}
+
+	m.purge()
+
+	wk := makeKey(k)
+	m.data.Compute(wk, func(old value[V], ok bool) (value[V], bool) {
+		if !ok {
+			old.cleanup = runtime.AddCleanup(k, m.dead.add, wk)
+		}
+		old.val = val
+		return old, true
+	})
+}
+
+// Get gets a value from the map relating to the passed key.
+func (m *WeakMap[K, V]) Get(k *K) (val V, ok bool) {
+	if k == nil {
+		return val, false
+	}
+
+	m.purge()
+
+	v, ok := m.data.Get(makeKey(k))
+	return v.val, ok
+}
+
+// Remove deletes a value from the map relating to the passed key. It returns
+// the removed value and true, or the zero value and false if the key wasn't
+// present.
+func (m *WeakMap[K, V]) Remove(k *K) (val V, ok bool) {
+	if k == nil {
+		return val, false
+	}
+
+	m.purge()
+
+	v, ok := m.data.Remove(makeKey(k))
+	if ok {
+		v.cleanup.Stop()
+	}
+	return v.val, ok
+}
+
+// ContainsKey returns true if the passed key is present, false if not.
+func (m *WeakMap[K, V]) ContainsKey(k *K) bool {
+	_, ok := m.Get(k)
+	return ok
+}
+
+// Clear empties the entire map.
+func (m *WeakMap[K, V]) Clear() {
+	m.data.ForEach(func(k key[K], v value[V]) {
+		v.cleanup.Stop()
+	})
+
+	m.data.Clear()
+	m.dead.take()
+}
+
+// ForEach iterates over the dataset within the map, calling the passed
+// function for each value. Only keys that haven't been collected are passed.
+func (m *WeakMap[K, V]) ForEach(f func(key *K, val V)) {
+	m.purge()
+
+	m.data.ForEach(func(k key[K], v value[V]) {
+		if p := k.ptr.Value(); p != nil {
+			f(p, v.val)
+		}
+	})
+}
+
+// Clone returns a copy of the map that shares no entries with the original.
+// The keys themselves are shared, and entries are dropped from both maps once
+// a key is collected.
+func (m *WeakMap[K, V]) Clone() *WeakMap[K, V] {
+	c := New[K, V]()
+	m.ForEach(func(k *K, val V) {
+		c.Put(k, val)
+	})
+	return c
+}
+
+// Equal returns true if both maps contain the same keys relating to the same
+// values, false if not.
+func (m *WeakMap[K, V]) Equal(other *WeakMap[K, V]) bool {
+	if m.Count() != other.Count() {
+		return false
+	}
+
+	result := true
+	m.ForEach(func(k *K, val V) {
+		if v, ok := other.Get(k); !ok || v != val {
+			result = false
+		}
+	})
+
+	return result
+}
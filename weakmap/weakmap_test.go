@@ -0,0 +1,245 @@
+package weakmap
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nomad-software/assert"
+)
+
+// Object is a key type large enough, and containing a pointer, so the runtime
+// always collects it on its own.
+type object struct {
+	name string
+	data [4]int
+}
+
+// Collect runs the garbage collector until the passed condition is true,
+// failing the test if it never is.
+func collect(t *testing.T, cond func() bool) {
+	for i := 0; i < 100; i++ {
+		runtime.GC()
+		if cond() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("keys were never collected")
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	m := New[object, int]()
+	assert.True(t, m.Empty())
+
+	a := &object{name: "a"}
+	b := &object{name: "a"}
+
+	m.Put(a, 1)
+	m.Put(b, 2)
+	m.Put(a, 3)
+	assert.Eq(t, m.Count(), 2)
+
+	// Keys are compared by address, not by value.
+	val, ok := m.Get(a)
+	assert.True(t, ok)
+	assert.Eq(t, val, 3)
+
+	val, ok = m.Get(b)
+	assert.True(t, ok)
+	assert.Eq(t, val, 2)
+
+	assert.False(t, m.ContainsKey(&object{name: "a"}))
+	assert.False(t, m.ContainsKey(nil))
+}
+
+func TestFailedPut(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("no panic detected")
+		}
+	}()
+
+	m := New[object, int]()
+	m.Put(nil, 1)
+}
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	m := New[object, string]()
+	a := &object{name: "a"}
+	m.Put(a, "foo")
+
+	val, ok := m.Remove(a)
+	assert.True(t, ok)
+	assert.Eq(t, val, "foo")
+
+	_, ok = m.Remove(a)
+	assert.False(t, ok)
+	_, ok = m.Remove(nil)
+	assert.False(t, ok)
+	assert.True(t, m.Empty())
+}
+
+func TestCollected(t *testing.T) {
+	t.Parallel()
+
+	m := New[object, int]()
+	keep := &object{name: "keep"}
+	m.Put(keep, -1)
+
+	for i := 0; i < 100; i++ {
+		m.Put(&object{name: strconv.Itoa(i)}, i)
+	}
+	assert.Eq(t, m.Count(), 101)
+
+	collect(t, func() bool {
+		return m.Count() == 1
+	})
+
+	val, ok := m.Get(keep)
+	assert.True(t, ok)
+	assert.Eq(t, val, -1)
+
+	runtime.KeepAlive(keep)
+}
+
+func TestCollectedAfterRemove(t *testing.T) {
+	t.Parallel()
+
+	m := New[object, int]()
+	keep := &object{name: "keep"}
+	m.Put(keep, 1)
+	m.Remove(keep)
+	m.Put(keep, 2)
+
+	func() {
+		a := &object{name: "a"}
+		m.Put(a, 1)
+		m.Remove(a)
+	}()
+
+	// The cleanup of a removed key is stopped, so only the dropped key is
+	// ever queued.
+	for i := 0; i < 5; i++ {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	assert.Eq(t, len(m.dead.take()), 0)
+	assert.Eq(t, m.Count(), 1)
+
+	runtime.KeepAlive(keep)
+}
+
+func TestClear(t *testing.T) {
+	t.Parallel()
+
+	m := New[object, int]()
+	a := &object{name: "a"}
+	m.Put(a, 1)
+	m.Put(&object{name: "b"}, 2)
+
+	m.Clear()
+	assert.True(t, m.Empty())
+
+	m.Put(a, 3)
+	val, _ := m.Get(a)
+	assert.Eq(t, val, 3)
+
+	runtime.KeepAlive(a)
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	m := New[object, int]()
+	keys := make([]*object, 10)
+	for i := range keys {
+		keys[i] = &object{name: strconv.Itoa(i)}
+		m.Put(keys[i], i)
+	}
+
+	var sum int
+	m.ForEach(func(key *object, val int) {
+		assert.Eq(t, key.name, strconv.Itoa(val))
+		sum += val
+	})
+	assert.Eq(t, sum, 45)
+
+	runtime.KeepAlive(keys)
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	m := New[object, int]()
+	a := &object{name: "a"}
+	b := &object{name: "b"}
+	m.Put(a, 1)
+	m.Put(b, 2)
+
+	c := m.Clone()
+	assert.True(t, c.Equal(m))
+
+	c.Put(a, 3)
+	m.Remove(b)
+	assert.False(t, c.Equal(m))
+
+	val, _ := m.Get(a)
+	assert.Eq(t, val, 1)
+	assert.True(t, c.ContainsKey(b))
+
+	runtime.KeepAlive(a)
+	runtime.KeepAlive(b)
+}
+
+func TestCloneCollected(t *testing.T) {
+	t.Parallel()
+
+	m := New[object, int]()
+	for i := 0; i < 10; i++ {
+		m.Put(&object{name: strconv.Itoa(i)}, i)
+	}
+	c := m.Clone()
+
+	collect(t, func() bool {
+		return m.Count() == 0 && c.Count() == 0
+	})
+}
+
+func BenchmarkWeakMapPut(b *testing.B) {
+	m := New[object, int]()
+	keys := make([]*object, 1_000)
+	for i := range keys {
+		keys[i] = &object{}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Put(keys[x%len(keys)], x)
+	}
+}
+
+func BenchmarkWeakMapGet(b *testing.B) {
+	m := New[object, int]()
+	keys := make([]*object, 1_000)
+	for i := range keys {
+		keys[i] = &object{}
+		m.Put(keys[i], i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for x := 0; x < b.N; x++ {
+		m.Get(keys[x%len(keys)])
+	}
+}